@@ -0,0 +1,151 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+const roleScript = `
+var el = arguments[0];
+var role = (el.getAttribute("role") || "").trim().split(/\s+/)[0];
+if (role) {
+	return role;
+}
+var tag = el.tagName.toLowerCase();
+var type = (el.getAttribute("type") || "text").toLowerCase();
+switch (tag) {
+case "a":
+case "area":
+	return el.hasAttribute("href") ? "link" : "";
+case "input":
+	return {
+		button: "button", image: "button", reset: "button", submit: "button",
+		checkbox: "checkbox", radio: "radio", range: "slider", number: "spinbutton",
+		search: "searchbox", email: "textbox", tel: "textbox", text: "textbox", url: "textbox"
+	}[type] || "";
+case "img":
+	return el.getAttribute("alt") === "" ? "presentation" : "img";
+case "select":
+	return el.multiple || el.size > 1 ? "listbox" : "combobox";
+case "section":
+	return el.hasAttribute("aria-label") || el.hasAttribute("aria-labelledby") ? "region" : "";
+case "h1": case "h2": case "h3": case "h4": case "h5": case "h6":
+	return "heading";
+}
+return {
+	article: "article", aside: "complementary", button: "button", dialog: "dialog", footer: "contentinfo",
+	form: "form", header: "banner", hr: "separator", li: "listitem", main: "main", nav: "navigation",
+	ol: "list", option: "option", progress: "progressbar", table: "table", tbody: "rowgroup", td: "cell",
+	textarea: "textbox", th: "columnheader", thead: "rowgroup", tr: "row", ul: "list"
+}[tag] || "";`
+
+const accessibleNameScript = `
+var el = arguments[0];
+var clean = function(s) { return (s || "").replace(/\s+/g, " ").trim(); };
+var labelledBy = el.getAttribute("aria-labelledby");
+if (labelledBy) {
+	var names = labelledBy.trim().split(/\s+/).map(function(id) {
+		var label = document.getElementById(id);
+		return label ? clean(label.textContent) : "";
+	}).filter(function(s) { return s; });
+	if (names.length) {
+		return [names.join(" "), "from aria-labelledby '" + labelledBy + "'"];
+	}
+}
+var label = clean(el.getAttribute("aria-label"));
+if (label) {
+	return [label, "from aria-label"];
+}
+if (el.labels && el.labels.length) {
+	var text = Array.prototype.map.call(el.labels, function(l) { return clean(l.textContent); }).join(" ");
+	if (text) {
+		return [text, "from the associated <label>"];
+	}
+}
+var tag = el.tagName.toLowerCase();
+if ((tag === "img" || tag === "area" || (tag === "input" && el.type === "image")) && el.hasAttribute("alt")) {
+	return [clean(el.getAttribute("alt")), "from the alt attribute"];
+}
+if (["a", "button", "h1", "h2", "h3", "h4", "h5", "h6", "summary", "td", "th", "option", "li"].indexOf(tag) !== -1 ||
+	["button", "link", "heading", "tab", "menuitem", "cell"].indexOf(el.getAttribute("role")) !== -1) {
+	var content = clean(el.textContent);
+	if (content) {
+		return [content, "from the element's text content"];
+	}
+}
+var title = clean(el.getAttribute("title"));
+if (title) {
+	return [title, "from the title attribute"];
+}
+return ["", "no aria-labelledby, aria-label, label, alt, text content or title was found"];`
+
+// Role tests the elements' ARIA role, which is the first role in the role attribute if there is one, otherwise the
+// implicit role of the element's tag, such as "button" for <button> and <input type="submit">, or "link" for
+// <a href>.  Elements without a role have an empty role
+func (e *Elements) Role() *StringMatch {
+	return &StringMatch{
+		testName: "Role",
+		value: func(we selenium.WebElement) (string, error) {
+			result, err := e.seq.driver.ExecuteScript(roleScript, []interface{}{we})
+			if err != nil {
+				return "", err
+			}
+			role, _ := result.(string)
+			return role, nil
+		},
+		e: e,
+	}
+}
+
+// AccessibleName tests the name assistive technology gives the elements.  The name comes from the first of the
+// elements referenced by aria-labelledby, aria-label, the associated <label>, the alt attribute of images, the text
+// content of buttons, links and headings, or the title attribute.  Failures say which of those the name came from
+func (e *Elements) AccessibleName() *StringMatch {
+	source := ""
+	return &StringMatch{
+		testName: "Accessible Name",
+		value: func(we selenium.WebElement) (string, error) {
+			result, err := e.seq.driver.ExecuteScript(accessibleNameScript, []interface{}{we})
+			if err != nil {
+				return "", err
+			}
+			values, ok := result.([]interface{})
+			if !ok || len(values) != 2 {
+				return "", fmt.Errorf("Unexpected accessible name result %v", result)
+			}
+			name, _ := values[0].(string)
+			source, _ = values[1].(string)
+			return name, nil
+		},
+		source: func() string {
+			return "accessible name " + source
+		},
+		e: e,
+	}
+}
+
+// ImagesHaveAlt tests that every selected element is an <img> with an alt attribute.  An empty alt attribute
+// passes, as it marks the image as decorative
+func (e *Elements) ImagesHaveAlt() *Elements {
+	return e.each("Images Have Alt", func(i int, we selenium.WebElement) error {
+		tag, err := we.TagName()
+		if err != nil {
+			return err
+		}
+		if tag != "img" {
+			return fmt.Errorf("Element is a <%s>, not an <img>", tag)
+		}
+		_, ok, err := e.seq.attribute(we, "alt")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("Image has no alt attribute")
+		}
+		return nil
+	})
+}
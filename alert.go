@@ -0,0 +1,64 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+var errNoAlert = errors.New("No alert is present on the page")
+
+// alertErr replaces the driver's error when no alert is open with a clearer one
+func alertErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if sErr, ok := err.(*selenium.Error); ok && sErr.Err == "no such alert" {
+		return errNoAlert
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "no such alert") ||
+		strings.Contains(strings.ToLower(err.Error()), "no alert open") {
+		return errNoAlert
+	}
+	return err
+}
+
+// AcceptAlert accepts the currently open alert, confirm or prompt
+func (s *Sequence) AcceptAlert() *Sequence {
+	return s.test("Accept Alert", func(d selenium.WebDriver) error {
+		return alertErr(d.AcceptAlert())
+	})
+}
+
+// DismissAlert dismisses the currently open alert, confirm or prompt
+func (s *Sequence) DismissAlert() *Sequence {
+	return s.test("Dismiss Alert", func(d selenium.WebDriver) error {
+		return alertErr(d.DismissAlert())
+	})
+}
+
+// AnswerPrompt enters the text into the currently open prompt and accepts it
+func (s *Sequence) AnswerPrompt(text string) *Sequence {
+	return s.test("Answer Prompt", func(d selenium.WebDriver) error {
+		err := d.SetAlertText(text)
+		if err != nil {
+			return alertErr(err)
+		}
+		return alertErr(d.AcceptAlert())
+	})
+}
+
+// AlertText tests against the text of the currently open alert, confirm or prompt
+func (s *Sequence) AlertText() *ValueMatch {
+	return &ValueMatch{
+		testName: "Alert Text",
+		value: func() (string, error) {
+			text, err := s.driver.AlertText()
+			return text, alertErr(err)
+		},
+		s: s,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ScreenshotOnError takes a screenshot into the passed in directory whenever the sequence fails, named with the time
+// and stage of the failure.  The screenshot's path is stored on the Error, and failing to take the screenshot never
+// replaces the original error
+func (s *Sequence) ScreenshotOnError(dir string) *Sequence {
+	s.screenshotDir = dir
+	return s
+}
+
+// errorScreenshot takes a screenshot of the page for the passed in error
+func (s *Sequence) errorScreenshot(err *Error) {
+	if s.screenshotDir == "" {
+		return
+	}
+	filename := filepath.Join(s.screenshotDir, fmt.Sprintf("%s-%s.png", err.timestamp.Format("20060102-150405.000"),
+		fileSafe(err.Stage)))
+
+	err.screenshotErr = func() error {
+		buff, sErr := s.driver.Screenshot()
+		if sErr != nil {
+			return sErr
+		}
+		sErr = os.MkdirAll(s.screenshotDir, 0755)
+		if sErr != nil {
+			return sErr
+		}
+		return ioutil.WriteFile(filename, buff, 0644)
+	}()
+	if err.screenshotErr == nil {
+		err.Screenshot = filename
+	}
+}
+
+// fileSafe replaces any characters in str which shouldn't be used in a file name
+func fileSafe(str string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, str), "-")
+}
+
+// ArtifactsOnError writes a directory of artifacts for the first failure in the sequence into the passed in
+// directory: a screenshot, the page's source, url and browser log, and the error itself.  The directory is named
+// with the test's name when the sequence is ended with Ok.  The directory can contain tokens such as {test} and
+// {time}, see Screenshot.  Failing to write the artifacts never replaces the original error
+func (s *Sequence) ArtifactsOnError(dir string) *Sequence {
+	s.artifactsDir = dir
+	return s
+}
+
+// errorArtifacts writes the failure artifacts for the passed in error, if they haven't already been written for
+// an earlier error
+func (s *Sequence) errorArtifacts(err *Error) {
+	if s.artifactsDir == "" || s.artifactsWritten {
+		return
+	}
+	s.artifactsWritten = true
+
+	var errs Errors
+	prefix := fileSafe(err.Stage)
+	if s.testName != "" {
+		prefix = fileSafe(s.testName) + "-" + prefix
+	}
+
+	dir, dErr := func() (string, error) {
+		parent, dErr := s.filename(s.artifactsDir)
+		if dErr != nil {
+			return "", dErr
+		}
+		dErr = os.MkdirAll(parent, 0755)
+		if dErr != nil {
+			return "", dErr
+		}
+		return ioutil.TempDir(parent, prefix+"-")
+	}()
+	if dErr != nil {
+		err.artifactsErr = dErr
+		return
+	}
+	err.Artifacts = dir
+
+	write := func(name string, data []byte) {
+		wErr := ioutil.WriteFile(filepath.Join(dir, name), data, 0644)
+		if wErr != nil {
+			errs = append(errs, wErr)
+		}
+	}
+
+	write("error.txt", []byte(err.Error()))
+	write("url.txt", []byte(redact(err.url, s.redacted)))
+
+	buff, sErr := s.driver.Screenshot()
+	if sErr != nil {
+		errs = append(errs, fmt.Errorf("Taking screenshot: %s", sErr))
+	} else {
+		write("screenshot.png", buff)
+	}
+
+	src, sErr := s.driver.PageSource()
+	if sErr != nil {
+		errs = append(errs, fmt.Errorf("Reading page source: %s", sErr))
+	} else {
+		write("source.html", []byte(redact(src, s.redacted)))
+	}
+
+	write("log.txt", []byte(redact(s.debugLog(), s.redacted)))
+
+	if len(errs) != 0 {
+		err.artifactsErr = errs
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultBaselineThreshold is the ratio of pixels which can differ from a baseline image by default
+const defaultBaselineThreshold = 0.001
+
+type baselineOptions struct {
+	threshold float64
+	record    bool
+}
+
+// BaselineOption is an option for comparing screenshots against baseline images
+type BaselineOption func(o *baselineOptions)
+
+// BaselineThreshold sets the ratio of pixels which can differ from the baseline before the comparison fails, such as
+// 0.01 for 1%.  The default is 0.001
+func BaselineThreshold(ratio float64) BaselineOption {
+	return func(o *baselineOptions) {
+		o.threshold = ratio
+	}
+}
+
+// BaselineRecord writes the screenshot as the baseline if the baseline image doesn't exist yet, and passes
+func BaselineRecord() BaselineOption {
+	return func(o *baselineOptions) {
+		o.record = true
+	}
+}
+
+// MatchesBaseline takes a screenshot and compares it pixel by pixel against the PNG image at baselinePath.  If more
+// pixels differ than the threshold allows, the screenshot and an image highlighting the differing pixels are written
+// next to the baseline, as name.actual.png and name.diff.png
+func (s *Sequence) MatchesBaseline(baselinePath string, opts ...BaselineOption) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Matches Baseline", "", 1) {
+			return s
+		}
+		buff, err := s.driver.Screenshot()
+		if err == nil {
+			err = matchBaseline(buff, baselinePath, opts)
+		}
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Matches Baseline",
+				Err:    err,
+				Caller: s.caller(1),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// MatchesBaseline takes a screenshot of the selected element and compares it against the PNG image at baselinePath,
+// see Sequence.MatchesBaseline.  The selection must be a single element
+func (e *Elements) MatchesBaseline(baselinePath string, opts ...BaselineOption) *Elements {
+	stage := "Element Matches Baseline"
+	if e.seq.failed() {
+		return e
+	}
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 1) {
+			return e
+		}
+		var err error
+		if len(e.elems) != 1 {
+			err = fmt.Errorf("Selector '%s' matched %d elements, element screenshots need exactly one", e.selector,
+				len(e.elems))
+		} else {
+			var buff []byte
+			buff, err = e.seq.elementScreenshot(e.elems[0])
+			if err == nil {
+				err = matchBaseline(buff, baselinePath, opts)
+			}
+		}
+		if err != nil {
+			e.seq.err = &Error{
+				Stage:    stage,
+				Selector: e.selector,
+				Err:      err,
+				Caller:   e.seq.caller(1),
+			}
+		}
+		return e
+	}
+	return e.last()
+}
+
+// matchBaseline compares the PNG screenshot against the baseline image
+func matchBaseline(buff []byte, baselinePath string, opts []BaselineOption) error {
+	o := &baselineOptions{threshold: defaultBaselineThreshold}
+	for i := range opts {
+		opts[i](o)
+	}
+
+	baselineBuff, err := ioutil.ReadFile(baselinePath)
+	if os.IsNotExist(err) && o.record {
+		err = os.MkdirAll(filepath.Dir(baselinePath), 0755)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(baselinePath, buff, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("Reading the baseline image failed: %s", err)
+	}
+	baseline, err := png.Decode(bytes.NewReader(baselineBuff))
+	if err != nil {
+		return fmt.Errorf("Decoding the baseline image %s failed: %s", baselinePath, err)
+	}
+	actual, err := png.Decode(bytes.NewReader(buff))
+	if err != nil {
+		return fmt.Errorf("Decoding the screenshot failed: %s", err)
+	}
+
+	ext := filepath.Ext(baselinePath)
+	base := strings.TrimSuffix(baselinePath, ext)
+	actualPath := base + ".actual" + ext
+	diffPath := base + ".diff" + ext
+
+	if baseline.Bounds().Size() != actual.Bounds().Size() {
+		err = ioutil.WriteFile(actualPath, buff, 0644)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("The screenshot is %s but the baseline %s is %s. Screenshot written to %s",
+			actual.Bounds().Size(), baselinePath, baseline.Bounds().Size(), actualPath)
+	}
+
+	diff, count := diffImages(baseline, actual)
+	ratio := float64(count) / float64(actual.Bounds().Dx()*actual.Bounds().Dy())
+	if ratio <= o.threshold {
+		return nil
+	}
+
+	err = ioutil.WriteFile(actualPath, buff, 0644)
+	if err != nil {
+		return err
+	}
+	var diffBuff bytes.Buffer
+	err = png.Encode(&diffBuff, diff)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(diffPath, diffBuff.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%.2f%% of pixels differ from the baseline %s, more than the %.2f%% allowed. Screenshot "+
+		"written to %s and differences to %s", ratio*100, baselinePath, o.threshold*100, actualPath, diffPath)
+}
+
+// diffImages returns an image of the actual image faded, with the pixels which differ from the baseline in red,
+// and the number of differing pixels.  The images must be the same size
+func diffImages(baseline, actual image.Image) (*image.RGBA, int) {
+	bounds := actual.Bounds()
+	offset := baseline.Bounds().Min.Sub(bounds.Min)
+	diff := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	highlight := color.RGBA{R: 255, A: 255}
+	count := 0
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, a1 := actual.At(x, y).RGBA()
+			r2, g2, b2, a2 := baseline.At(x+offset.X, y+offset.Y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				count++
+				diff.Set(x-bounds.Min.X, y-bounds.Min.Y, highlight)
+				continue
+			}
+			gray := color.GrayModel.Convert(actual.At(x, y)).(color.Gray)
+			faded := uint8(191 + int(gray.Y)/4)
+			diff.Set(x-bounds.Min.X, y-bounds.Min.Y, color.RGBA{R: faded, G: faded, B: faded, A: 255})
+		}
+	}
+	return diff, count
+}
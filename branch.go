@@ -0,0 +1,64 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import "github.com/tebeka/selenium"
+
+// If runs the then branch if cond returns true, otherwise it runs the otherwise branch.  Either branch can be nil.
+// Errors in the branch that runs stop the sequence as normal, and an error from cond fails the sequence with the
+// Condition stage
+func (s *Sequence) If(cond func(d selenium.WebDriver) (bool, error),
+	then, otherwise func(s *Sequence) *Sequence) *Sequence {
+	return s.branch("", cond, then, otherwise)
+}
+
+// IfElement runs the then branch only if the selector currently matches at least one visible element, such as a
+// cookie consent banner that may or may not be shown.  It doesn't wait for the element to appear
+func (s *Sequence) IfElement(selector string, then func(s *Sequence) *Sequence) *Sequence {
+	return s.branch(selector, func(d selenium.WebDriver) (bool, error) {
+		elems, err := d.FindElements(selenium.ByCSSSelector, selector)
+		if err != nil {
+			return false, err
+		}
+		for i := range elems {
+			ok, err := elems[i].IsDisplayed()
+			if err != nil {
+				if isStaleElement(err) {
+					continue
+				}
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, then, nil)
+}
+
+func (s *Sequence) branch(selector string, cond func(d selenium.WebDriver) (bool, error),
+	then, otherwise func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+	if s.stopped("Condition", selector, 2) {
+		return s
+	}
+	ok, err := cond(s.driver)
+	if err != nil {
+		s.err = &Error{
+			Stage:    "Condition",
+			Selector: selector,
+			Err:      err,
+			Caller:   s.caller(2),
+		}
+		return s
+	}
+	if ok && then != nil {
+		return s.Use(then)
+	}
+	if !ok && otherwise != nil {
+		return s.Use(otherwise)
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrCaptureMultipleElements is returned when Into is used on a selection of more than one element
+var ErrCaptureMultipleElements = errors.New("Cannot capture from multiple elements, use IntoSlice instead")
+
+// Into stores the string value of the selected element in dest, so it can be used later in the sequence, such as
+// an order number which should show on the next page.  The selection must be a single element
+func (s *StringMatch) Into(dest *string) *Elements {
+	return s.capture("Into", func(vals []string) error {
+		if len(vals) == 0 {
+			return s.e.noElements()
+		}
+		if len(vals) > 1 {
+			return fmt.Errorf("Selector '%s' matched %d elements: %w", s.e.selector, len(vals),
+				ErrCaptureMultipleElements)
+		}
+		*dest = vals[0]
+		return nil
+	})
+}
+
+// IntoSlice stores the string values of all of the selected elements in dest, in the order they were selected
+func (s *StringMatch) IntoSlice(dest *[]string) *Elements {
+	return s.capture("Into Slice", func(vals []string) error {
+		*dest = vals
+		return nil
+	})
+}
+
+// Texts stores the text of every selected element in dest, in DOM order.  An empty selection stores an empty slice
+func (e *Elements) Texts(dest *[]string) *Elements {
+	defer e.AddCallerSkip(1).AddCallerSkip(-1)
+	return e.Text().IntoSlice(dest)
+}
+
+// AttributeValues stores the value of the attribute from every selected element in dest, in DOM order.  An empty
+// selection stores an empty slice
+func (e *Elements) AttributeValues(attribute string, dest *[]string) *Elements {
+	defer e.AddCallerSkip(1).AddCallerSkip(-1)
+	return e.Attribute(attribute).IntoSlice(dest)
+}
+
+// capture reads the string value of every selected element and passes them to fn
+func (s *StringMatch) capture(testName string, fn func(vals []string) error) *Elements {
+	e := s.e
+	stage := s.testName + " " + testName
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 2) {
+			return e
+		}
+		vals := make([]string, 0, len(e.elems))
+		for i := range e.elems {
+			val, err := s.value(e.elems[i])
+			if err != nil {
+				e.seq.err = &Error{
+					Stage:       stage,
+					Element:     e.elems[i],
+					ElementHTML: e.seq.elementHTML(e.elems[i]),
+					Selector:    e.selector,
+					Err:         fmt.Errorf("Reading element %d of %d failed: %w", i+1, len(e.elems), err),
+					Caller:      e.seq.caller(2),
+				}
+				return e
+			}
+			vals = append(vals, val)
+		}
+		if err := fn(vals); err != nil {
+			e.seq.err = &Error{
+				Stage:    stage,
+				Selector: e.selector,
+				Err:      err,
+				Caller:   e.seq.caller(2),
+			}
+		}
+		return e
+	}
+	return e.last()
+}
+
+// Into stores the page's title in dest, so it can be used later in the sequence
+func (t *TitleMatch) Into(dest *string) *Sequence {
+	return t.test("Into", func() error {
+		*dest = t.title
+		return nil
+	})
+}
+
+// Into stores the page's URL in dest, so it can be used later in the sequence
+func (u *URLMatch) Into(dest *url.URL) *Sequence {
+	return u.test("Into", func() error {
+		*dest = *u.url
+		return nil
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// cssColor is an RGBA color, with red, green and blue from 0 to 255, and alpha from 0 to 1
+type cssColor struct {
+	r, g, b, a float64
+}
+
+func (c cssColor) String() string {
+	return fmt.Sprintf("rgba(%s, %s, %s, %s)", formatChannel(c.r), formatChannel(c.g), formatChannel(c.b),
+		formatChannel(c.a))
+}
+
+func formatChannel(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// near returns whether each channel of the colors is within tolerance of each other.  Alpha is compared on the same
+// 0 to 255 scale as the other channels
+func (c cssColor) near(other cssColor, tolerance float64) bool {
+	const epsilon = 0.5
+	return math.Abs(c.r-other.r) <= tolerance+epsilon &&
+		math.Abs(c.g-other.g) <= tolerance+epsilon &&
+		math.Abs(c.b-other.b) <= tolerance+epsilon &&
+		math.Abs(c.a-other.a)*255 <= tolerance+epsilon
+}
+
+// EqualsColor tests if the string value is the same color as the passed in value, regardless of how either is
+// written.  Hex (#f00, #ff0000, #ff0000ff), rgb(), rgba() and named colors are supported, so rgba(255, 0, 0, 1)
+// equals #ff0000 and red
+func (s *StringMatch) EqualsColor(match string) *Elements {
+	return s.colorTest("Equals Color", match, 0)
+}
+
+// EqualsColorWithin tests if the string value is a color whose red, green, blue and alpha channels are each within
+// tolerance (out of 255) of the passed in color, for colors which are blended or antialiased
+func (s *StringMatch) EqualsColorWithin(match string, tolerance int) *Elements {
+	return s.colorTest("Equals Color Within", match, tolerance)
+}
+
+func (s *StringMatch) colorTest(testName, match string, tolerance int) *Elements {
+	defer s.e.AddCallerSkip(1).AddCallerSkip(-1)
+	return s.test(fmt.Sprintf("%s %s", s.testName, testName), func(we selenium.WebElement) error {
+		want, err := parseColor(match)
+		if err != nil {
+			return err
+		}
+		val, err := s.value(we)
+		if err != nil {
+			return err
+		}
+		got, err := parseColor(val)
+		if err != nil {
+			return fmt.Errorf("The element's %s: %s", s.testName, err)
+		}
+		if !got.near(want, float64(tolerance)) {
+			if tolerance > 0 {
+				return fmt.Errorf("The element's %s is not within %d of the color '%s' (%s). Got '%s' (%s)",
+					s.testName, tolerance, match, want, val, got)
+			}
+			return fmt.Errorf("The element's %s does not equal the color '%s' (%s). Got '%s' (%s)", s.testName,
+				match, want, val, got)
+		}
+		return nil
+	})
+}
+
+// parseColor parses a CSS color value
+func parseColor(value string) (cssColor, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(v, "#") {
+		return parseHexColor(value, v[1:])
+	}
+	if strings.HasPrefix(v, "rgb(") || strings.HasPrefix(v, "rgba(") {
+		return parseRGBColor(value, v)
+	}
+	if v == "transparent" {
+		return cssColor{}, nil
+	}
+	if hex, ok := namedColors[v]; ok {
+		return parseHexColor(value, hex)
+	}
+	return cssColor{}, fmt.Errorf("'%s' is not a recognized color", value)
+}
+
+func parseHexColor(value, hex string) (cssColor, error) {
+	if len(hex) == 3 || len(hex) == 4 {
+		expanded := ""
+		for i := range hex {
+			expanded += strings.Repeat(string(hex[i]), 2)
+		}
+		hex = expanded
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return cssColor{}, fmt.Errorf("'%s' is not a valid hex color", value)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return cssColor{}, fmt.Errorf("'%s' is not a valid hex color", value)
+	}
+	return cssColor{
+		r: float64(n >> 24 & 0xff),
+		g: float64(n >> 16 & 0xff),
+		b: float64(n >> 8 & 0xff),
+		a: float64(n&0xff) / 255,
+	}, nil
+}
+
+func parseRGBColor(value, v string) (cssColor, error) {
+	invalid := fmt.Errorf("'%s' is not a valid rgb color", value)
+	if !strings.HasSuffix(v, ")") {
+		return cssColor{}, invalid
+	}
+	args := v[strings.Index(v, "(")+1 : len(v)-1]
+	parts := strings.FieldsFunc(args, func(r rune) bool {
+		return r == ',' || r == '/' || r == ' '
+	})
+	if len(parts) != 3 && len(parts) != 4 {
+		return cssColor{}, invalid
+	}
+	channels := []float64{0, 0, 0, 1}
+	for i := range parts {
+		percent := strings.HasSuffix(parts[i], "%")
+		n, err := strconv.ParseFloat(strings.TrimSuffix(parts[i], "%"), 64)
+		if err != nil {
+			return cssColor{}, invalid
+		}
+		switch {
+		case percent && i == 3:
+			n /= 100
+		case percent:
+			n = n * 255 / 100
+		}
+		channels[i] = n
+	}
+	return cssColor{r: channels[0], g: channels[1], b: channels[2], a: channels[3]}, nil
+}
+
+// namedColors are the CSS named colors and their hex values
+var namedColors = map[string]string{
+	"aliceblue": "f0f8ff", "antiquewhite": "faebd7", "aqua": "00ffff", "aquamarine": "7fffd4",
+	"azure": "f0ffff", "beige": "f5f5dc", "bisque": "ffe4c4", "black": "000000",
+	"blanchedalmond": "ffebcd", "blue": "0000ff", "blueviolet": "8a2be2", "brown": "a52a2a",
+	"burlywood": "deb887", "cadetblue": "5f9ea0", "chartreuse": "7fff00", "chocolate": "d2691e",
+	"coral": "ff7f50", "cornflowerblue": "6495ed", "cornsilk": "fff8dc", "crimson": "dc143c",
+	"cyan": "00ffff", "darkblue": "00008b", "darkcyan": "008b8b", "darkgoldenrod": "b8860b",
+	"darkgray": "a9a9a9", "darkgreen": "006400", "darkgrey": "a9a9a9", "darkkhaki": "bdb76b",
+	"darkmagenta": "8b008b", "darkolivegreen": "556b2f", "darkorange": "ff8c00", "darkorchid": "9932cc",
+	"darkred": "8b0000", "darksalmon": "e9967a", "darkseagreen": "8fbc8f", "darkslateblue": "483d8b",
+	"darkslategray": "2f4f4f", "darkslategrey": "2f4f4f", "darkturquoise": "00ced1", "darkviolet": "9400d3",
+	"deeppink": "ff1493", "deepskyblue": "00bfff", "dimgray": "696969", "dimgrey": "696969",
+	"dodgerblue": "1e90ff", "firebrick": "b22222", "floralwhite": "fffaf0", "forestgreen": "228b22",
+	"fuchsia": "ff00ff", "gainsboro": "dcdcdc", "ghostwhite": "f8f8ff", "gold": "ffd700",
+	"goldenrod": "daa520", "gray": "808080", "green": "008000", "greenyellow": "adff2f",
+	"grey": "808080", "honeydew": "f0fff0", "hotpink": "ff69b4", "indianred": "cd5c5c",
+	"indigo": "4b0082", "ivory": "fffff0", "khaki": "f0e68c", "lavender": "e6e6fa",
+	"lavenderblush": "fff0f5", "lawngreen": "7cfc00", "lemonchiffon": "fffacd", "lightblue": "add8e6",
+	"lightcoral": "f08080", "lightcyan": "e0ffff", "lightgoldenrodyellow": "fafad2", "lightgray": "d3d3d3",
+	"lightgreen": "90ee90", "lightgrey": "d3d3d3", "lightpink": "ffb6c1", "lightsalmon": "ffa07a",
+	"lightseagreen": "20b2aa", "lightskyblue": "87cefa", "lightslategray": "778899", "lightslategrey": "778899",
+	"lightsteelblue": "b0c4de", "lightyellow": "ffffe0", "lime": "00ff00", "limegreen": "32cd32",
+	"linen": "faf0e6", "magenta": "ff00ff", "maroon": "800000", "mediumaquamarine": "66cdaa",
+	"mediumblue": "0000cd", "mediumorchid": "ba55d3", "mediumpurple": "9370db", "mediumseagreen": "3cb371",
+	"mediumslateblue": "7b68ee", "mediumspringgreen": "00fa9a", "mediumturquoise": "48d1cc",
+	"mediumvioletred": "c71585", "midnightblue": "191970", "mintcream": "f5fffa", "mistyrose": "ffe4e1",
+	"moccasin": "ffe4b5", "navajowhite": "ffdead", "navy": "000080", "oldlace": "fdf5e6",
+	"olive": "808000", "olivedrab": "6b8e23", "orange": "ffa500", "orangered": "ff4500",
+	"orchid": "da70d6", "palegoldenrod": "eee8aa", "palegreen": "98fb98", "paleturquoise": "afeeee",
+	"palevioletred": "db7093", "papayawhip": "ffefd5", "peachpuff": "ffdab9", "peru": "cd853f",
+	"pink": "ffc0cb", "plum": "dda0dd", "powderblue": "b0e0e6", "purple": "800080",
+	"rebeccapurple": "663399", "red": "ff0000", "rosybrown": "bc8f8f", "royalblue": "4169e1",
+	"saddlebrown": "8b4513", "salmon": "fa8072", "sandybrown": "f4a460", "seagreen": "2e8b57",
+	"seashell": "fff5ee", "sienna": "a0522d", "silver": "c0c0c0", "skyblue": "87ceeb",
+	"slateblue": "6a5acd", "slategray": "708090", "slategrey": "708090", "snow": "fffafa",
+	"springgreen": "00ff7f", "steelblue": "4682b4", "tan": "d2b48c", "teal": "008080",
+	"thistle": "d8bfd8", "tomato": "ff6347", "turquoise": "40e0d0", "violet": "ee82ee",
+	"wheat": "f5deb3", "white": "ffffff", "whitesmoke": "f5f5f5", "yellow": "ffff00",
+	"yellowgreen": "9acd32",
+}
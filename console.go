@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/tebeka/selenium"
+	"github.com/tebeka/selenium/log"
+)
+
+// browserLog reads any new entries from the browser's log and returns every entry read so far in the sequence.
+// The WebDriver log endpoint drains entries as they are read, so they are accumulated on the sequence
+func (s *Sequence) browserLog() ([]log.Message, error) {
+	messages, err := s.driver.Log(log.Browser)
+	if err != nil {
+		return nil, err
+	}
+	s.logs = append(s.logs, messages...)
+	return s.logs, nil
+}
+
+// IgnoreJSErrors registers patterns for javascript errors which NoJSErrors should ignore, such as known errors
+// from third party scripts
+func (s *Sequence) IgnoreJSErrors(patterns ...*regexp.Regexp) *Sequence {
+	s.ignoreJSErrors = append(s.ignoreJSErrors, patterns...)
+	return s
+}
+
+// NoJSErrors tests that the browser hasn't logged any javascript errors (SEVERE level log entries) during the
+// sequence, other than those matching patterns registered with IgnoreJSErrors
+func (s *Sequence) NoJSErrors() *Sequence {
+	return s.test("No JS Errors", func(d selenium.WebDriver) error {
+		messages, err := s.browserLog()
+		if err != nil {
+			return err
+		}
+
+		errs := ""
+		count := 0
+	MESSAGES:
+		for i := range messages {
+			if messages[i].Level != log.Severe {
+				continue
+			}
+			for j := range s.ignoreJSErrors {
+				if s.ignoreJSErrors[j].MatchString(messages[i].Message) {
+					continue MESSAGES
+				}
+			}
+			count++
+			errs += fmt.Sprintf("\n\t%s: %s", messages[i].Timestamp.Format(time.StampMilli), messages[i].Message)
+		}
+		if count > 0 {
+			return fmt.Errorf("%d javascript errors were logged: %s", count, errs)
+		}
+		return nil
+	})
+}
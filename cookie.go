@@ -0,0 +1,193 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tebeka/selenium"
+)
+
+// SetCookie adds the cookie to the current page
+func (s *Sequence) SetCookie(c selenium.Cookie) *Sequence {
+	return s.test("Set Cookie", func(d selenium.WebDriver) error {
+		return d.AddCookie(&c)
+	})
+}
+
+// DeleteCookie deletes the cookie with the passed in name
+func (s *Sequence) DeleteCookie(name string) *Sequence {
+	return s.test("Delete Cookie", func(d selenium.WebDriver) error {
+		return d.DeleteCookie(name)
+	})
+}
+
+// DeleteAllCookies deletes all of the cookies for the current page
+func (s *Sequence) DeleteAllCookies() *Sequence {
+	return s.test("Delete All Cookies", func(d selenium.WebDriver) error {
+		return d.DeleteAllCookies()
+	})
+}
+
+// CookieMatch is for testing a cookie on the current page
+type CookieMatch struct {
+	name string
+	s    *Sequence
+}
+
+// Cookie tests against the cookie with the passed in name
+func (s *Sequence) Cookie(name string) *CookieMatch {
+	return &CookieMatch{
+		name: name,
+		s:    s,
+	}
+}
+
+// cookie returns the cookie, or nil if no cookie with the name exists
+func (c *CookieMatch) cookie() (*selenium.Cookie, error) {
+	cookies, err := c.s.driver.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+	for i := range cookies {
+		if cookies[i].Name == c.name {
+			return &cookies[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *CookieMatch) test(testName string, fn func(cookie *selenium.Cookie) error) *Sequence {
+	stage := "Cookie " + testName
+	c.s.last = func() *Sequence {
+		if c.s.failed() {
+			return c.s
+		}
+		if c.s.stopped(stage, "", 2) {
+			return c.s
+		}
+		cookie, err := c.cookie()
+		if err == nil {
+			err = fn(cookie)
+		}
+		if err != nil {
+			c.s.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: c.s.caller(2),
+			}
+		}
+		return c.s
+	}
+	return c.s.last()
+}
+
+// Exists tests if the cookie exists
+func (c *CookieMatch) Exists() *Sequence {
+	return c.test("Exists", func(cookie *selenium.Cookie) error {
+		if cookie == nil {
+			return fmt.Errorf("The cookie '%s' does not exist", c.name)
+		}
+		return nil
+	})
+}
+
+// Absent tests if the cookie does not exist
+func (c *CookieMatch) Absent() *Sequence {
+	return c.test("Absent", func(cookie *selenium.Cookie) error {
+		if cookie != nil {
+			return fmt.Errorf("The cookie '%s' exists with the value '%s'", c.name, cookie.Value)
+		}
+		return nil
+	})
+}
+
+// Secure tests if the cookie exists and has the secure flag set
+func (c *CookieMatch) Secure() *Sequence {
+	return c.test("Secure", func(cookie *selenium.Cookie) error {
+		if cookie == nil {
+			return fmt.Errorf("The cookie '%s' does not exist", c.name)
+		}
+		if !cookie.Secure {
+			return fmt.Errorf("The cookie '%s' is not secure", c.name)
+		}
+		return nil
+	})
+}
+
+// HTTPOnly tests if the cookie exists and has the http only flag set.  The selenium package doesn't expose the flag,
+// so the cookie is read from the WebDriver server directly, which needs the sequence's RemoteURL to be set, see Start
+func (c *CookieMatch) HTTPOnly() *Sequence {
+	return c.test("HTTP Only", func(cookie *selenium.Cookie) error {
+		if cookie == nil {
+			return fmt.Errorf("The cookie '%s' does not exist", c.name)
+		}
+		httpOnly, err := c.httpOnly()
+		if err != nil {
+			return err
+		}
+		if !httpOnly {
+			return fmt.Errorf("The cookie '%s' is not http only", c.name)
+		}
+		return nil
+	})
+}
+
+// httpOnly reads whether the cookie has the http only flag set from the WebDriver server's cookies
+func (c *CookieMatch) httpOnly() (bool, error) {
+	if c.s.RemoteURL == "" {
+		return false, errors.New("Checking the http only flag needs the sequence's RemoteURL to be set")
+	}
+	res, err := c.s.remoteRequest(http.MethodGet, "/cookie", nil)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	result := struct {
+		Value json.RawMessage `json:"value"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return false, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Remote WebDriver returned status %d: %s", res.StatusCode, result.Value)
+	}
+
+	var cookies []struct {
+		Name     string `json:"name"`
+		HTTPOnly bool   `json:"httpOnly"`
+	}
+	err = json.Unmarshal(result.Value, &cookies)
+	if err != nil {
+		return false, fmt.Errorf("Unexpected response from remote WebDriver: %s", result.Value)
+	}
+	for i := range cookies {
+		if cookies[i].Name == c.name {
+			return cookies[i].HTTPOnly, nil
+		}
+	}
+	return false, fmt.Errorf("The cookie '%s' does not exist on the remote WebDriver", c.name)
+}
+
+// Value tests against the value of the cookie.  The test fails if the cookie doesn't exist
+func (c *CookieMatch) Value() *ValueMatch {
+	return &ValueMatch{
+		testName: fmt.Sprintf("Cookie %s Value", c.name),
+		value: func() (string, error) {
+			cookie, err := c.cookie()
+			if err != nil {
+				return "", err
+			}
+			if cookie == nil {
+				return "", fmt.Errorf("The cookie '%s' does not exist", c.name)
+			}
+			return cookie.Value, nil
+		},
+		s: c.s,
+	}
+}
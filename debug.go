@@ -0,0 +1,133 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+type debugOptions struct {
+	maxSource int
+	selector  string
+	noSource  bool
+}
+
+// DebugOption is an option for limiting what Debug prints
+type DebugOption func(o *debugOptions)
+
+// DebugMaxSource truncates the printed source to n characters.  Truncated source is indented first, so the part
+// which is printed is readable even if the page's HTML is minified
+func DebugMaxSource(n int) DebugOption {
+	return func(o *debugOptions) {
+		o.maxSource = n
+	}
+}
+
+// DebugSelector prints the outer HTML of the elements matching the selector instead of the page's whole source
+func DebugSelector(selector string) DebugOption {
+	return func(o *debugOptions) {
+		o.selector = selector
+	}
+}
+
+// DebugNoSource leaves the source out, printing only the page's title, URL and the browser's log
+func DebugNoSource() DebugOption {
+	return func(o *debugOptions) {
+		o.noSource = true
+	}
+}
+
+// debugSource returns the source to print for Debug
+func (s *Sequence) debugSource(o *debugOptions) (string, error) {
+	if o.noSource {
+		return "", nil
+	}
+
+	var src string
+	if o.selector == "" {
+		var err error
+		src, err = s.driver.PageSource()
+		if err != nil {
+			return "", err
+		}
+	} else {
+		elems, err := s.driver.FindElements(selenium.ByCSSSelector, o.selector)
+		if err != nil {
+			return "", err
+		}
+		if len(elems) == 0 {
+			return fmt.Sprintf("(no elements match the selector '%s')", o.selector), nil
+		}
+		html := make([]string, len(elems))
+		for i := range elems {
+			html[i], err = s.html(elems[i], "outerHTML")
+			if err != nil {
+				return "", err
+			}
+		}
+		src = strings.Join(html, "\n")
+	}
+
+	if o.maxSource <= 0 || len(src) <= o.maxSource {
+		return src, nil
+	}
+	src = indentHTML(src)
+	if len(src) <= o.maxSource {
+		return src, nil
+	}
+	return fmt.Sprintf("%s\n... (%d more characters)", src[:o.maxSource], len(src)-o.maxSource), nil
+}
+
+// htmlTag matches an HTML tag, comment or doctype
+var htmlTag = regexp.MustCompile(`<!--[\s\S]*?-->|<[^>]+>`)
+
+// voidElements are the HTML elements which have no closing tag
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+	"link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// indentHTML puts each tag of the HTML on its own line, indented by how deeply it's nested
+func indentHTML(src string) string {
+	var out strings.Builder
+	depth := 0
+	line := func(str string) {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			return
+		}
+		out.WriteString(strings.Repeat("  ", depth))
+		out.WriteString(str)
+		out.WriteString("\n")
+	}
+
+	last := 0
+	for _, loc := range htmlTag.FindAllStringIndex(src, -1) {
+		line(src[last:loc[0]])
+		tag := src[loc[0]:loc[1]]
+		last = loc[1]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			if depth > 0 {
+				depth--
+			}
+			line(tag)
+		case strings.HasPrefix(tag, "<!") || strings.HasSuffix(tag, "/>"):
+			line(tag)
+		default:
+			line(tag)
+			name := strings.FieldsFunc(tag[1:], func(r rune) bool {
+				return r == ' ' || r == '>' || r == '\n' || r == '\t' || r == '/'
+			})
+			if len(name) > 0 && !voidElements[strings.ToLower(name[0])] {
+				depth++
+			}
+		}
+	}
+	line(src[last:])
+	return strings.TrimSuffix(out.String(), "\n")
+}
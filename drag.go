@@ -0,0 +1,132 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+// DragMethod determines how drag and drop actions are performed
+type DragMethod int
+
+const (
+	// DragNative drags using native WebDriver mouse actions
+	DragNative DragMethod = iota
+	// DragScript drags by dispatching HTML5 drag and drop events via javascript.  Useful for pages that rely on
+	// the HTML5 drag events, which native actions often fail to trigger
+	DragScript
+)
+
+const dragScript = `
+var source = arguments[0];
+var target = arguments[1];
+if (!target) {
+	var rect = source.getBoundingClientRect();
+	target = document.elementFromPoint(rect.left + rect.width / 2 + arguments[2],
+		rect.top + rect.height / 2 + arguments[3]);
+	if (!target) {
+		throw new Error("No element exists at the drop offset");
+	}
+}
+var data = new DataTransfer();
+function fire(element, type) {
+	element.dispatchEvent(new DragEvent(type, {bubbles: true, cancelable: true, dataTransfer: data}));
+}
+fire(source, "dragstart");
+fire(target, "dragenter");
+fire(target, "dragover");
+fire(target, "drop");
+fire(source, "dragend");`
+
+// DragTo drags the selected elements to the center of the single element matching the target selector
+func (e *Elements) DragTo(targetSelector string) *Elements {
+	return e.test("Drag To", func(we selenium.WebElement) error {
+		targets, err := e.seq.driver.FindElements(selenium.ByCSSSelector, targetSelector)
+		if err != nil {
+			return err
+		}
+		target, err := dragTarget(targets, targetSelector)
+		if err != nil {
+			return err
+		}
+		return e.seq.drag(we, target, 0, 0)
+	})
+}
+
+// DragToElements drags the selected elements to the center of the single element selected in target.  The target
+// is re-selected before each drag, so it isn't stale when the page has changed since it was selected
+func (e *Elements) DragToElements(target *Elements) *Elements {
+	return e.test("Drag To Elements", func(we selenium.WebElement) error {
+		targets := target.elems
+		if target.selectFunc != nil && target.selector != "" {
+			var err error
+			targets, err = target.selectFunc(target.selector)
+			if err != nil {
+				return err
+			}
+		}
+		t, err := dragTarget(targets, target.selector)
+		if err != nil {
+			return err
+		}
+		return e.seq.drag(we, t, 0, 0)
+	})
+}
+
+// DragByOffset drags the selected elements by the passed in offset in pixels from their center
+func (e *Elements) DragByOffset(dx, dy int) *Elements {
+	return e.test("Drag By Offset", func(we selenium.WebElement) error {
+		return e.seq.drag(we, nil, dx, dy)
+	})
+}
+
+func dragTarget(targets []selenium.WebElement, selector string) (selenium.WebElement, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("No elements exist for the drag target selector '%s'", selector)
+	}
+	if len(targets) > 1 {
+		return nil, fmt.Errorf("Drag target selector '%s' matched %d elements, it must match exactly one",
+			selector, len(targets))
+	}
+	return targets[0], nil
+}
+
+// drag drags the source element to the center of the target element, or by the offset from the source's center
+// if target is nil
+func (s *Sequence) drag(source, target selenium.WebElement, dx, dy int) error {
+	if s.DragMethod == DragScript {
+		_, err := s.driver.ExecuteScript(dragScript, []interface{}{source, target, dx, dy})
+		return err
+	}
+
+	size, err := source.Size()
+	if err != nil {
+		return err
+	}
+	err = source.MoveTo(size.Width/2, size.Height/2)
+	if err != nil {
+		return err
+	}
+	err = s.driver.ButtonDown()
+	if err != nil {
+		return err
+	}
+
+	if target != nil {
+		size, err = target.Size()
+		if err == nil {
+			err = target.MoveTo(size.Width/2, size.Height/2)
+		}
+	} else {
+		err = source.MoveTo(size.Width/2+dx, size.Height/2+dy)
+	}
+	if err != nil {
+		// release the button so it isn't left held down for the rest of the sequence
+		s.driver.ButtonUp()
+		return err
+	}
+
+	return s.driver.ButtonUp()
+}
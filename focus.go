@@ -0,0 +1,60 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"errors"
+
+	"github.com/tebeka/selenium"
+)
+
+// activeElementSelector describes the selection made by ActiveElement in errors
+const activeElementSelector = "(active element)"
+
+// Focused tests if the element is the one with focus, such as after pressing tab, or opening a dialog
+func (e *Elements) Focused() *Elements {
+	return e.test("Focused", func(we selenium.WebElement) error {
+		result, err := e.seq.driver.ExecuteScript("return arguments[0] === document.activeElement;",
+			[]interface{}{we})
+		if err != nil {
+			return err
+		}
+		if focused, _ := result.(bool); !focused {
+			return errors.New("Element does not have focus")
+		}
+		return nil
+	})
+}
+
+// ActiveElement selects the element which currently has focus, so it can be tested like any other selection.
+// Eventually re-selects the element with focus
+func (s *Sequence) ActiveElement() *Elements {
+	e := &Elements{
+		seq:      s,
+		selector: activeElementSelector,
+		selectFunc: func(string) ([]selenium.WebElement, error) {
+			return s.scriptElements("return document.activeElement ? [document.activeElement] : [];")
+		},
+	}
+
+	if s.failed() {
+		return e
+	}
+
+	e.last = func() *Elements {
+		if s.stopped("Active Element", "", 1) {
+			return e
+		}
+		var err error
+		e.elems, err = e.selectFunc(e.selector)
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Active Element",
+				Err:    err,
+				Caller: s.caller(1),
+			}
+		}
+		return e
+	}
+	return e.last()
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// FillForm sets the values of the fields in the form matching the formSelector.  The keys of the values map are the
+// name (or failing that, the id) of the field to set.  Text inputs and textareas are cleared and typed into, selects
+// have the option with the matching visible text chosen, checkboxes are checked or unchecked with "true" or "false",
+// and radio buttons have the radio with the matching value clicked.  Fields not in the map are left untouched
+func (s *Sequence) FillForm(formSelector string, values map[string]string) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Fill Form", formSelector, 1) {
+			return s
+		}
+
+		forms, err := s.driver.FindElements(selenium.ByCSSSelector, formSelector)
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Fill Form",
+				Err:    err,
+				Caller: s.caller(1),
+			}
+			return s
+		}
+		if len(forms) != 1 {
+			s.err = &Error{
+				Stage: "Fill Form",
+				Err: fmt.Errorf("Form selector '%s' matched %d elements, it must match exactly one", formSelector,
+					len(forms)),
+				Caller: s.caller(1),
+			}
+			return s
+		}
+
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			field, err := s.fillField(forms[0], name, values[name])
+			if err != nil {
+				s.err = &Error{
+					Stage:   "Fill Form",
+					Element: field,
+					Err:     fmt.Errorf("Setting field '%s' failed: %s", name, err),
+					Caller:  s.caller(1),
+				}
+				return s
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// fillField sets the value of the field in the form, returning the field element
+func (s *Sequence) fillField(form selenium.WebElement, name, value string) (selenium.WebElement, error) {
+	fields, err := form.FindElements(selenium.ByCSSSelector, fmt.Sprintf("[name=%q]", name))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		fields, err = form.FindElements(selenium.ByCSSSelector, fmt.Sprintf("[id=%q]", name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("No field with the name or id '%s' exists in the form", name)
+	}
+
+	field := fields[0]
+	tag, err := field.TagName()
+	if err != nil {
+		return field, err
+	}
+	fieldType, err := field.GetAttribute("type")
+	if err != nil {
+		return field, err
+	}
+
+	switch {
+	case tag == "select":
+		return field, s.selectOption(field, fmt.Sprintf("with the text '%s'", value),
+			func(i int, option selenium.WebElement) (bool, error) {
+				text, err := option.Text()
+				return text == value, err
+			})
+	case tag == "input" && strings.ToLower(fieldType) == "checkbox":
+		checked, err := strconv.ParseBool(value)
+		if err != nil {
+			return field, fmt.Errorf("Checkbox values must be 'true' or 'false', got '%s'", value)
+		}
+		return field, setChecked(field, checked)
+	case tag == "input" && strings.ToLower(fieldType) == "radio":
+		for i := range fields {
+			radioValue, err := fields[i].GetAttribute("value")
+			if err != nil {
+				return fields[i], err
+			}
+			if radioValue == value {
+				return fields[i], fields[i].Click()
+			}
+		}
+		return field, fmt.Errorf("No radio button has the value '%s'", value)
+	default:
+		return field, setText(field, value, false)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+// SwitchToFrame switches the driver into the iframe matching the selector.  Frames can be nested by switching
+// into a frame from inside another frame
+func (s *Sequence) SwitchToFrame(selector string) *Sequence {
+	return s.test("Switch To Frame", func(d selenium.WebDriver) error {
+		frames, err := d.FindElements(selenium.ByCSSSelector, selector)
+		if err != nil {
+			return err
+		}
+		if len(frames) != 1 {
+			return fmt.Errorf("Frame selector '%s' matched %d elements, it must match exactly one", selector,
+				len(frames))
+		}
+		return s.switchFrame(frames[0])
+	})
+}
+
+// SwitchToParentFrame switches the driver out of the current frame and into its parent
+func (s *Sequence) SwitchToParentFrame() *Sequence {
+	return s.test("Switch To Parent Frame", func(d selenium.WebDriver) error {
+		if len(s.frames) == 0 {
+			return fmt.Errorf("The sequence is not in a frame")
+		}
+		parents := s.frames[:len(s.frames)-1]
+
+		err := d.SwitchFrame(nil)
+		if err != nil {
+			return err
+		}
+		// WebDriver has no portable way to switch to the parent frame, so re-enter each of the parent frames
+		// from the top of the document
+		for i := range parents {
+			err = d.SwitchFrame(parents[i])
+			if err != nil {
+				return fmt.Errorf("Re-entering parent frame %s failed: %s", elementString(parents[i]), err)
+			}
+		}
+		s.frames = parents
+		return nil
+	})
+}
+
+// SwitchToDefaultContent switches the driver out of all frames and back to the top level document
+func (s *Sequence) SwitchToDefaultContent() *Sequence {
+	return s.test("Switch To Default Content", func(d selenium.WebDriver) error {
+		err := d.SwitchFrame(nil)
+		if err != nil {
+			return err
+		}
+		s.frames = nil
+		return nil
+	})
+}
+
+func (s *Sequence) switchFrame(frame selenium.WebElement) error {
+	err := s.driver.SwitchFrame(frame)
+	if err != nil {
+		return err
+	}
+	s.frames = append(s.frames, frame)
+	return nil
+}
+
+// AsFrame switches the driver into the selected iframe element, and continues the sequence inside it
+func (e *Elements) AsFrame() *Sequence {
+	s := e.seq
+	if s.failed() {
+		return s
+	}
+
+	s.last = func() *Sequence {
+		if s.stopped("As Frame", e.selector, 1) {
+			return s
+		}
+		if e.selectFunc != nil {
+			var err error
+			e.elems, err = e.selectFunc(e.selector)
+			if err != nil {
+				s.err = &Error{
+					Stage:  "As Frame",
+					Err:    err,
+					Caller: e.seq.caller(1),
+				}
+				return s
+			}
+		}
+		if len(e.elems) != 1 {
+			s.err = &Error{
+				Stage: "As Frame",
+				Err: fmt.Errorf("Selector '%s' matched %d elements, it must match exactly one frame", e.selector,
+					len(e.elems)),
+				Caller: e.seq.caller(1),
+			}
+			return s
+		}
+		err := s.switchFrame(e.elems[0])
+		if err != nil {
+			s.err = &Error{
+				Stage:   "As Frame",
+				Element: e.elems[0],
+				Err:     err,
+				Caller:  e.seq.caller(1),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// navigated clears the frames the sequence is in if navigating succeeded, since navigating returns the driver to the
+// top level document
+func (s *Sequence) navigated(err error) error {
+	if err == nil {
+		s.frames = nil
+	}
+	return err
+}
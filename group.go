@@ -0,0 +1,112 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// groupSeparator separates the names of nested groups
+const groupSeparator = " > "
+
+// Group runs the steps in fn as a named group.  Errors from steps in the group have their stage prefixed with the
+// group's name, and nested groups are joined like "check out > payment"
+func (s *Sequence) Group(name string, fn func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+	s.groups = append(s.groups, name)
+	skip := s.callerSkip
+	fn(s)
+	s.callerSkip = skip
+	if s.err != nil {
+		s.tagGroup(s.err)
+	}
+	s.groups = s.groups[:len(s.groups)-1]
+	return s
+}
+
+// ForEach runs the steps in fn once for each of the cases, as a group labeled with the case's index and value, such
+// as "login #2 (bob)".  The sequence stops at the first case that fails, use ForEachAll to run every case
+func (s *Sequence) ForEach(name string, cases []interface{}, fn func(s *Sequence, c interface{}) *Sequence) *Sequence {
+	for i := range cases {
+		c := cases[i]
+		s.Group(caseName(name, i, c), func(s *Sequence) *Sequence {
+			return fn(s, c)
+		})
+	}
+	return s
+}
+
+// ForEachAll runs the steps in fn for each of the cases the same as ForEach, but carries on through the remaining
+// cases when one fails.  The failures from every case are returned together as Errors by End
+func (s *Sequence) ForEachAll(name string, cases []interface{},
+	fn func(s *Sequence, c interface{}) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+	for i := range cases {
+		c := cases[i]
+		s.Group(caseName(name, i, c), func(s *Sequence) *Sequence {
+			return fn(s, c)
+		})
+		if s.err != nil {
+			s.endStep()
+			s.softErrs = append(s.softErrs, s.err)
+			s.err = nil
+		}
+	}
+	return s
+}
+
+// caseName returns the group name for a ForEach case
+func caseName(name string, index int, c interface{}) string {
+	return fmt.Sprintf("%s #%d (%v)", name, index, c)
+}
+
+// groupPath returns the names of the groups currently running
+func (s *Sequence) groupPath() string {
+	return strings.Join(s.groups, groupSeparator)
+}
+
+// tagGroup records the currently running group on the error, unless it's already been recorded by a nested group
+func (s *Sequence) tagGroup(err *Error) {
+	if err.Group != "" || len(s.groups) == 0 {
+		return
+	}
+	err.Group = s.groupPath()
+	err.Stage = err.Group + groupSeparator + err.Stage
+}
+
+// ungroupedStage returns the error's stage without its group prefix
+func ungroupedStage(err *Error) string {
+	if err.Group == "" {
+		return err.Stage
+	}
+	return strings.TrimPrefix(err.Stage, err.Group+groupSeparator)
+}
+
+// Use runs a reusable fragment of a sequence, such as a page object helper, without breaking the chain.  Fragments
+// can use other fragments.  To have errors in a fragment point at the line calling Use, rather than inside the
+// fragment, call AddCallerSkip(2) at the start of the fragment
+func (s *Sequence) Use(fn func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+	skip := s.callerSkip
+	fn(s)
+	s.callerSkip = skip
+	return s
+}
+
+// Use runs a reusable fragment of a sequence against the elements without breaking the chain, see Sequence.Use
+func (e *Elements) Use(fn func(e *Elements) *Elements) *Elements {
+	if e.seq.failed() {
+		return e
+	}
+	skip := e.seq.callerSkip
+	fn(e)
+	e.seq.callerSkip = skip
+	return e
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// JSONMatch is for testing the JSON value of strings in elements, such as data attributes or the contents of
+// <script type="application/json"> elements.  Paths are dotted keys, with array elements selected by their index,
+// such as "items.0.name"
+type JSONMatch struct {
+	testName string
+	value    func(selenium.WebElement) (interface{}, error)
+	e        *Elements
+}
+
+// AsJSON parses the string value as JSON
+func (s *StringMatch) AsJSON() *JSONMatch {
+	return &JSONMatch{
+		testName: s.testName,
+		value: func(we selenium.WebElement) (interface{}, error) {
+			val, err := s.value(we)
+			if err != nil {
+				return nil, err
+			}
+			var result interface{}
+			err = json.Unmarshal([]byte(val), &result)
+			if err != nil {
+				index := 0
+				var sErr *json.SyntaxError
+				if errors.As(err, &sErr) {
+					index = int(sErr.Offset) - 1
+				}
+				if index < 0 || index > len(val) {
+					index = 0
+				}
+				return nil, fmt.Errorf("The element's %s is not valid JSON: %s. Got '%s'", s.testName, err,
+					excerpt(val, index, 0))
+			}
+			return result, nil
+		},
+		e: s.e,
+	}
+}
+
+// check parses the element's value before running the JSON comparison
+func (j *JSONMatch) check(fn func(val interface{}) error) func(selenium.WebElement) error {
+	return func(we selenium.WebElement) error {
+		val, err := j.value(we)
+		if err != nil {
+			return err
+		}
+		return fn(val)
+	}
+}
+
+// HasKey tests if the JSON value has a value at the path
+func (j *JSONMatch) HasKey(path string) *Elements {
+	return j.e.test(j.testName+" JSON Has Key", j.check(func(val interface{}) error {
+		_, err := jsonPath(val, path)
+		if err != nil {
+			return fmt.Errorf("The element's %s JSON does not have the key '%s': %s", j.testName, path, err)
+		}
+		return nil
+	}))
+}
+
+// ValueEquals tests if the value at the path in the JSON equals want, once want is converted to JSON
+func (j *JSONMatch) ValueEquals(path string, want interface{}) *Elements {
+	return j.e.test(j.testName+" JSON Value Equals", j.check(func(val interface{}) error {
+		got, err := jsonPath(val, path)
+		if err != nil {
+			return fmt.Errorf("The element's %s JSON does not have the key '%s': %s", j.testName, path, err)
+		}
+		return jsonEquals(fmt.Sprintf("The element's %s JSON value at '%s'", j.testName, path), got, want)
+	}))
+}
+
+// Equals tests if the JSON value equals want, once want is converted to JSON
+func (j *JSONMatch) Equals(want interface{}) *Elements {
+	return j.e.test(j.testName+" JSON Equals", j.check(func(val interface{}) error {
+		return jsonEquals(fmt.Sprintf("The element's %s JSON", j.testName), val, want)
+	}))
+}
+
+// jsonEquals compares the decoded JSON value against want, by converting want to JSON and back so numbers, structs
+// and maps compare the same way as the decoded value
+func jsonEquals(name string, got, want interface{}) error {
+	data, err := json.Marshal(want)
+	if err != nil {
+		return fmt.Errorf("Expected value can't be converted to JSON: %s", err)
+	}
+	var expected interface{}
+	err = json.Unmarshal(data, &expected)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(got, expected) {
+		return nil
+	}
+	gotData, err := json.Marshal(got)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%s does not equal %s. Got %s", name, data, gotData)
+}
+
+// jsonPath returns the value at the dotted path in the decoded JSON value
+func jsonPath(val interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return val, nil
+	}
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("'%s' has no key '%s'", strings.Join(keys[:i], "."), key)
+			}
+			val = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("'%s' is an array of %d elements with no index '%s'",
+					strings.Join(keys[:i], "."), len(v), key)
+			}
+			val = v[index]
+		default:
+			return nil, fmt.Errorf("'%s' is not an object or array", strings.Join(keys[:i], "."))
+		}
+	}
+	return val, nil
+}
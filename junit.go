@@ -0,0 +1,118 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// JUnitReporter is a Reporter which writes each sequence as a JUnit style XML test suite, with a test case for each
+// step, so it can be read by CI servers
+type JUnitReporter struct {
+	w     io.Writer
+	name  string
+	lock  sync.Mutex
+	suite junitSuite
+}
+
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+
+	key string
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitReporter creates a reporter which writes the sequence to w as a JUnit test suite with the passed in name
+// when the sequence finishes
+func NewJUnitReporter(w io.Writer, name string) *JUnitReporter {
+	return &JUnitReporter{
+		w:    w,
+		name: name,
+	}
+}
+
+// StepStarted implements Reporter
+func (j *JUnitReporter) StepStarted(step StepEvent) {}
+
+// StepFinished implements Reporter.  Retries of a step replace the step's earlier attempt in the suite
+func (j *JUnitReporter) StepFinished(step StepEvent, err error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	name := step.Stage
+	if step.Group != "" {
+		name = step.Group + groupSeparator + name
+	}
+	if step.Selector != "" {
+		name += " '" + step.Selector + "'"
+	}
+	if step.Step != "" {
+		name += " [" + step.Step + "]"
+	}
+	c := junitCase{
+		Name:      name,
+		ClassName: step.Caller,
+		Time:      step.Duration.Seconds(),
+		key:       step.Group + "\x00" + step.Stage + "\x00" + step.Selector,
+	}
+	if err != nil {
+		c.Failure = &junitFailure{
+			Message: step.Stage + " failed",
+			Text:    err.Error(),
+		}
+		j.suite.Failures++
+	}
+	j.suite.Time += c.Time
+
+	if step.Attempt > 0 {
+		for i := len(j.suite.Cases) - 1; i >= 0; i-- {
+			if j.suite.Cases[i].key != c.key {
+				continue
+			}
+			if j.suite.Cases[i].Failure != nil {
+				j.suite.Failures--
+			}
+			c.Name += fmt.Sprintf(" (%d retries)", step.Attempt)
+			c.ClassName = j.suite.Cases[i].ClassName
+			c.Time += j.suite.Cases[i].Time
+			j.suite.Cases[i] = c
+			return
+		}
+	}
+	j.suite.Cases = append(j.suite.Cases, c)
+	j.suite.Tests++
+}
+
+// SequenceFinished implements Reporter, and writes the test suite
+func (j *JUnitReporter) SequenceFinished(err error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.suite.Name = j.name
+	data, xErr := xml.MarshalIndent(j.suite, "", "\t")
+	if xErr != nil {
+		return
+	}
+	j.w.Write([]byte(xml.Header))
+	j.w.Write(data)
+	j.w.Write([]byte("\n"))
+}
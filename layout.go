@@ -0,0 +1,196 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+// SizeMatch is for testing the rendered size of elements in pixels
+type SizeMatch struct {
+	tolerance int
+	e         *Elements
+}
+
+// Size tests the elements' rendered width and height
+func (e *Elements) Size() *SizeMatch {
+	return &SizeMatch{e: e}
+}
+
+// Tolerance allows sizes to be off by up to px pixels, as sub-pixel rendering differs between browsers
+func (m *SizeMatch) Tolerance(px int) *SizeMatch {
+	m.tolerance = px
+	return m
+}
+
+func (m *SizeMatch) test(testName, dimension string, fn func(val int) bool, wanted string) *Elements {
+	defer m.e.AddCallerSkip(1).AddCallerSkip(-1)
+	return m.e.test(testName, func(we selenium.WebElement) error {
+		size, err := we.Size()
+		if err != nil {
+			return err
+		}
+		val := size.Width
+		if dimension == "height" {
+			val = size.Height
+		}
+		if !fn(val) {
+			return fmt.Errorf("The element's %s is not %s. Got %dpx (%dx%d)", dimension, wanted, val, size.Width,
+				size.Height)
+		}
+		return nil
+	})
+}
+
+func (m *SizeMatch) wanted(desc string, px int) string {
+	if m.tolerance > 0 {
+		return fmt.Sprintf("%s %dpx (within %dpx)", desc, px, m.tolerance)
+	}
+	return fmt.Sprintf("%s %dpx", desc, px)
+}
+
+// WidthEquals tests if the elements are px pixels wide
+func (m *SizeMatch) WidthEquals(px int) *Elements {
+	return m.test("Width Equals", "width", m.equals(px), m.wanted("equal to", px))
+}
+
+// WidthAtLeast tests if the elements are at least px pixels wide
+func (m *SizeMatch) WidthAtLeast(px int) *Elements {
+	return m.test("Width At Least", "width", m.atLeast(px), m.wanted("at least", px))
+}
+
+// WidthAtMost tests if the elements are at most px pixels wide
+func (m *SizeMatch) WidthAtMost(px int) *Elements {
+	return m.test("Width At Most", "width", m.atMost(px), m.wanted("at most", px))
+}
+
+// HeightEquals tests if the elements are px pixels high
+func (m *SizeMatch) HeightEquals(px int) *Elements {
+	return m.test("Height Equals", "height", m.equals(px), m.wanted("equal to", px))
+}
+
+// HeightAtLeast tests if the elements are at least px pixels high
+func (m *SizeMatch) HeightAtLeast(px int) *Elements {
+	return m.test("Height At Least", "height", m.atLeast(px), m.wanted("at least", px))
+}
+
+// HeightAtMost tests if the elements are at most px pixels high
+func (m *SizeMatch) HeightAtMost(px int) *Elements {
+	return m.test("Height At Most", "height", m.atMost(px), m.wanted("at most", px))
+}
+
+func (m *SizeMatch) equals(px int) func(val int) bool {
+	return func(val int) bool {
+		return val >= px-m.tolerance && val <= px+m.tolerance
+	}
+}
+
+func (m *SizeMatch) atLeast(px int) func(val int) bool {
+	return func(val int) bool {
+		return val >= px-m.tolerance
+	}
+}
+
+func (m *SizeMatch) atMost(px int) func(val int) bool {
+	return func(val int) bool {
+		return val <= px+m.tolerance
+	}
+}
+
+// Edge is the edge of elements compared by AlignedWith
+type Edge string
+
+// Edges elements can be aligned on
+const (
+	Top  Edge = "top"
+	Left Edge = "left"
+)
+
+// LocationMatch is for testing the position of elements on the page in pixels
+type LocationMatch struct {
+	tolerance int
+	e         *Elements
+}
+
+// Location tests the elements' position on the page
+func (e *Elements) Location() *LocationMatch {
+	return &LocationMatch{e: e}
+}
+
+// Tolerance allows positions to be off by up to px pixels, as sub-pixel rendering differs between browsers
+func (m *LocationMatch) Tolerance(px int) *LocationMatch {
+	m.tolerance = px
+	return m
+}
+
+// XEquals tests if the left edge of the elements is px pixels from the left of the page
+func (m *LocationMatch) XEquals(px int) *Elements {
+	return m.e.test("X Equals", func(we selenium.WebElement) error {
+		point, err := we.Location()
+		if err != nil {
+			return err
+		}
+		if !withinPx(point.X, px, m.tolerance) {
+			return fmt.Errorf("The element's x position is not %dpx (within %dpx). Got %dpx", px, m.tolerance,
+				point.X)
+		}
+		return nil
+	})
+}
+
+// YEquals tests if the top edge of the elements is px pixels from the top of the page
+func (m *LocationMatch) YEquals(px int) *Elements {
+	return m.e.test("Y Equals", func(we selenium.WebElement) error {
+		point, err := we.Location()
+		if err != nil {
+			return err
+		}
+		if !withinPx(point.Y, px, m.tolerance) {
+			return fmt.Errorf("The element's y position is not %dpx (within %dpx). Got %dpx", px, m.tolerance,
+				point.Y)
+		}
+		return nil
+	})
+}
+
+// AlignedWith tests if the edge of the elements lines up with the same edge of the other element, such as Left
+// for elements stacked in a column, or Top for elements side by side in a row
+func (m *LocationMatch) AlignedWith(other *Elements, edge Edge) *Elements {
+	return m.e.test(fmt.Sprintf("Aligned %s", edge), func(we selenium.WebElement) error {
+		elems := other.elems
+		if other.selectFunc != nil && other.selector != "" {
+			var err error
+			elems, err = other.selectFunc(other.selector)
+			if err != nil {
+				return err
+			}
+		}
+		if len(elems) != 1 {
+			return fmt.Errorf("Selector '%s' matched %d elements, it must match exactly one to align with",
+				other.selector, len(elems))
+		}
+		otherPoint, err := elems[0].Location()
+		if err != nil {
+			return err
+		}
+		point, err := we.Location()
+		if err != nil {
+			return err
+		}
+		val, otherVal := point.X, otherPoint.X
+		if edge == Top {
+			val, otherVal = point.Y, otherPoint.Y
+		}
+		if !withinPx(val, otherVal, m.tolerance) {
+			return fmt.Errorf("The element's %s edge at %dpx is not aligned with '%s' at %dpx (within %dpx)", edge,
+				val, other.selector, otherVal, m.tolerance)
+		}
+		return nil
+	})
+}
+
+func withinPx(val, px, tolerance int) bool {
+	return val >= px-tolerance && val <= px+tolerance
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// NumberMatch is for testing the numeric value of strings in elements
+type NumberMatch struct {
+	testName string
+	value    func(selenium.WebElement) (float64, error)
+	e        *Elements
+}
+
+// AsInt parses the string value as an integer for numeric comparisons
+func (s *StringMatch) AsInt() *NumberMatch {
+	return &NumberMatch{
+		testName: s.testName,
+		value: func(we selenium.WebElement) (float64, error) {
+			val, err := s.value(we)
+			if err != nil {
+				return 0, err
+			}
+			i, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil {
+				return 0, fmt.Errorf("The element's %s '%s' is not an integer", s.testName, val)
+			}
+			return float64(i), nil
+		},
+		e: s.e,
+	}
+}
+
+// AsFloat parses the string value as a floating point number for numeric comparisons
+func (s *StringMatch) AsFloat() *NumberMatch {
+	return &NumberMatch{
+		testName: s.testName,
+		value: func(we selenium.WebElement) (float64, error) {
+			val, err := s.value(we)
+			if err != nil {
+				return 0, err
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			if err != nil {
+				return 0, fmt.Errorf("The element's %s '%s' is not a number", s.testName, val)
+			}
+			return f, nil
+		},
+		e: s.e,
+	}
+}
+
+// check parses the element's value before running the numeric comparison
+func (n *NumberMatch) check(fn func(val float64) error) func(selenium.WebElement) error {
+	return func(we selenium.WebElement) error {
+		val, err := n.value(we)
+		if err != nil {
+			return err
+		}
+		return fn(val)
+	}
+}
+
+// Equals tests if the numeric value equals the passed in value
+func (n *NumberMatch) Equals(match float64) *Elements {
+	return n.e.test(n.testName+" Equals", n.check(func(val float64) error {
+		if val != match {
+			return fmt.Errorf("The element's %s does not equal %v. Got %v", n.testName, match, val)
+		}
+		return nil
+	}))
+}
+
+// GreaterThan tests if the numeric value is greater than the passed in value
+func (n *NumberMatch) GreaterThan(match float64) *Elements {
+	return n.e.test(n.testName+" Greater Than", n.check(func(val float64) error {
+		if val <= match {
+			return fmt.Errorf("The element's %s is not greater than %v. Got %v", n.testName, match, val)
+		}
+		return nil
+	}))
+}
+
+// LessThan tests if the numeric value is less than the passed in value
+func (n *NumberMatch) LessThan(match float64) *Elements {
+	return n.e.test(n.testName+" Less Than", n.check(func(val float64) error {
+		if val >= match {
+			return fmt.Errorf("The element's %s is not less than %v. Got %v", n.testName, match, val)
+		}
+		return nil
+	}))
+}
+
+// AtLeast tests if the numeric value is greater than or equal to the passed in value
+func (n *NumberMatch) AtLeast(match float64) *Elements {
+	return n.e.test(n.testName+" At Least", n.check(func(val float64) error {
+		if val < match {
+			return fmt.Errorf("The element's %s is not at least %v. Got %v", n.testName, match, val)
+		}
+		return nil
+	}))
+}
+
+// AtMost tests if the numeric value is less than or equal to the passed in value
+func (n *NumberMatch) AtMost(match float64) *Elements {
+	return n.e.test(n.testName+" At Most", n.check(func(val float64) error {
+		if val > match {
+			return fmt.Errorf("The element's %s is not at most %v. Got %v", n.testName, match, val)
+		}
+		return nil
+	}))
+}
+
+// Between tests if the numeric value is between min and max inclusive
+func (n *NumberMatch) Between(min, max float64) *Elements {
+	return n.e.test(n.testName+" Between", n.check(func(val float64) error {
+		if val < min || val > max {
+			return fmt.Errorf("The element's %s is not between %v and %v. Got %v", n.testName, min, max, val)
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TextOrdered tests if the text of the selected elements is in order, in DOM order.  cmp reports whether a is in
+// order before b, and is called for each pair of neighbouring elements
+func (e *Elements) TextOrdered(cmp func(a, b string) bool) *Elements {
+	return e.ordered("Text Ordered", func(a, b string) (bool, error) {
+		return cmp(a, b), nil
+	})
+}
+
+// TextAscending tests if the text of the selected elements is sorted in ascending order
+func (e *Elements) TextAscending() *Elements {
+	return e.ordered("Text Ascending", func(a, b string) (bool, error) {
+		return a <= b, nil
+	})
+}
+
+// TextDescending tests if the text of the selected elements is sorted in descending order
+func (e *Elements) TextDescending() *Elements {
+	return e.ordered("Text Descending", func(a, b string) (bool, error) {
+		return a >= b, nil
+	})
+}
+
+// TextAscendingNumeric tests if the text of the selected elements is sorted in ascending order when parsed as numbers
+func (e *Elements) TextAscendingNumeric() *Elements {
+	return e.ordered("Text Ascending Numeric", numeric(func(a, b float64) bool {
+		return a <= b
+	}))
+}
+
+// TextDescendingNumeric tests if the text of the selected elements is sorted in descending order when parsed as
+// numbers
+func (e *Elements) TextDescendingNumeric() *Elements {
+	return e.ordered("Text Descending Numeric", numeric(func(a, b float64) bool {
+		return a >= b
+	}))
+}
+
+// numeric returns a comparison which parses both values as numbers before comparing them
+func numeric(cmp func(a, b float64) bool) func(a, b string) (bool, error) {
+	return func(a, b string) (bool, error) {
+		x, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+		if err != nil {
+			return false, fmt.Errorf("'%s' is not a number", a)
+		}
+		y, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if err != nil {
+			return false, fmt.Errorf("'%s' is not a number", b)
+		}
+		return cmp(x, y), nil
+	}
+}
+
+// ordered tests each neighbouring pair of the selected elements' text with cmp
+func (e *Elements) ordered(stage string, cmp func(a, b string) (bool, error)) *Elements {
+	if e.seq.failed() {
+		return e
+	}
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 2) {
+			return e
+		}
+		err := e.checkOrder(cmp)
+		if err != nil {
+			err.Stage = stage
+			err.Selector = e.selector
+			err.ElementHTML = e.seq.elementHTML(err.Element)
+			err.Caller = e.seq.caller(2)
+			e.seq.err = err
+		}
+		return e
+	}
+	return e.last()
+}
+
+func (e *Elements) checkOrder(cmp func(a, b string) (bool, error)) *Error {
+	if len(e.elems) == 0 {
+		return &Error{
+			Err: e.noElements(),
+		}
+	}
+	texts := make([]string, len(e.elems))
+	for i := range e.elems {
+		text, err := e.elems[i].Text()
+		if err != nil {
+			return &Error{
+				Element: e.elems[i],
+				Err:     err,
+			}
+		}
+		texts[i] = text
+	}
+	for i := 1; i < len(texts); i++ {
+		ok, err := cmp(texts[i-1], texts[i])
+		if err != nil {
+			return &Error{
+				Element: e.elems[i],
+				Err:     fmt.Errorf("Elements %d and %d could not be compared: %w", i, i+1, err),
+			}
+		}
+		if !ok {
+			return &Error{
+				Element: e.elems[i],
+				Err: fmt.Errorf("Elements %d and %d are out of order: '%s' then '%s'", i, i+1, texts[i-1],
+					texts[i]),
+			}
+		}
+	}
+	return nil
+}
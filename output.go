@@ -0,0 +1,45 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// SetOutput sets where Debug, DebugLog and Verbose, when it isn't given its own writer, print to.  The default is
+// stdout, or the test's log for anything printed while ending the sequence with Ok
+func (s *Sequence) SetOutput(w io.Writer) *Sequence {
+	s.output = w
+	return s
+}
+
+// out returns the writer the sequence prints to
+func (s *Sequence) out() io.Writer {
+	if s.output != nil {
+		return s.output
+	}
+	return os.Stdout
+}
+
+// sequenceOutput writes to the sequence's output at the time of writing, so it follows later calls to SetOutput
+type sequenceOutput struct {
+	s *Sequence
+}
+
+func (o sequenceOutput) Write(p []byte) (int, error) {
+	return o.s.out().Write(p)
+}
+
+// tbWriter writes to a test's log, so output is attributed to the test even when tests run in parallel
+type tbWriter struct {
+	tb testing.TB
+}
+
+func (w tbWriter) Write(p []byte) (int, error) {
+	w.tb.Helper()
+	w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tebeka/selenium"
+)
+
+// LoadMetric is a page load timing from the Navigation or Paint Timing APIs, measured from the start of the
+// navigation
+type LoadMetric string
+
+// Load metrics for LoadTimeUnder
+const (
+	DOMContentLoaded     LoadMetric = "domContentLoaded"
+	Load                 LoadMetric = "load"
+	DOMInteractive       LoadMetric = "domInteractive"
+	FirstContentfulPaint LoadMetric = "firstContentfulPaint"
+)
+
+// loadTimeScript returns the number of milliseconds from the start of the navigation until the metric passed in as
+// the first argument, or null if the browser hasn't recorded it (yet)
+const loadTimeScript = `
+	var metric = arguments[0];
+	if (metric === "firstContentfulPaint") {
+		var paint = performance.getEntriesByName ? performance.getEntriesByName("first-contentful-paint")[0] : null;
+		return paint ? paint.startTime : null;
+	}
+	var key = {
+		domContentLoaded: "domContentLoadedEventEnd",
+		load: "loadEventEnd",
+		domInteractive: "domInteractive"
+	}[metric];
+	var nav = performance.getEntriesByType ? performance.getEntriesByType("navigation")[0] : null;
+	if (nav) {
+		return nav[key] > 0 ? nav[key] - nav.startTime : null;
+	}
+	var timing = performance.timing;
+	return timing[key] > 0 ? timing[key] - timing.navigationStart : null;
+`
+
+// LoadTimeUnder tests that the current page loaded within the passed in duration.  By default the time until
+// DOMContentLoaded finished is measured, but another metric such as Load, DOMInteractive or FirstContentfulPaint can
+// be passed in instead
+func (s *Sequence) LoadTimeUnder(d time.Duration, metric ...LoadMetric) *Sequence {
+	m := DOMContentLoaded
+	if len(metric) > 0 {
+		m = metric[0]
+	}
+	return s.test("Load Time Under", func(driver selenium.WebDriver) error {
+		result, err := driver.ExecuteScript(loadTimeScript, []interface{}{string(m)})
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return fmt.Errorf("The browser has not recorded a %s time for the page", m)
+		}
+		ms, ok := result.(float64)
+		if !ok {
+			return fmt.Errorf("Unexpected %s time returned from the browser: %v", m, result)
+		}
+		took := time.Duration(ms * float64(time.Millisecond))
+		if took > d {
+			return fmt.Errorf("The page's %s time was %s which is over the budget of %s", m, took, d)
+		}
+		return nil
+	})
+}
+
+// resourceScript returns the number of resources the page has loaded, and the urls of the most recent ones
+const resourceScript = `
+	var entries = performance.getEntriesByType("resource");
+	return [entries.length, entries.slice(-5).map(function(e) { return e.name; })];
+`
+
+// WaitForNetworkIdle waits until the page hasn't loaded any new resources, such as XHR requests, images or scripts,
+// for the quiet duration.  Waiting is bounded by EventualTimeout and polled every EventualPoll
+func (s *Sequence) WaitForNetworkIdle(quiet time.Duration) *Sequence {
+	return s.test("Wait For Network Idle", func(d selenium.WebDriver) error {
+		start := -1
+		count := -1
+		var recent []interface{}
+		var changed time.Time
+
+		timeout := s.clampTimeout(s.EventualTimeout)
+		err := d.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+			if err := s.waitCancelled(); err != nil {
+				return false, err
+			}
+			result, err := d.ExecuteScript(resourceScript, nil)
+			if err != nil {
+				return false, err
+			}
+			values, ok := result.([]interface{})
+			if !ok || len(values) != 2 {
+				return false, fmt.Errorf("Unexpected resource entries returned from the browser: %v", result)
+			}
+			n, ok := values[0].(float64)
+			if !ok {
+				return false, fmt.Errorf("Unexpected resource count returned from the browser: %v", values[0])
+			}
+			recent, _ = values[1].([]interface{})
+			if start == -1 {
+				start = int(n)
+			}
+			if int(n) != count {
+				count = int(n)
+				changed = time.Now()
+			}
+			return time.Since(changed) >= quiet, nil
+		}, timeout, s.EventualPoll)
+		if err != nil {
+			if s.Context().Err() != nil {
+				return err
+			}
+			if start == -1 {
+				return err
+			}
+			urls := ""
+			for i := range recent {
+				urls += fmt.Sprintf("\n\t%v", recent[i])
+			}
+			return fmt.Errorf("The network was not idle for %s within %s. %d resources were loaded while waiting, "+
+				"the most recent were: %s", quiet, timeout, count-start, urls)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/tebeka/selenium"
+)
+
+// WaitForReady sets whether Get, Back, Forward and Refresh wait for the page's document.readyState to be
+// "complete" before continuing the sequence.  An optional javascript expression can be passed in which must also
+// evaluate to true before the page is considered ready, such as "window.appReady === true".  Waiting is bounded by
+// EventualTimeout and polled every EventualPoll
+func (s *Sequence) WaitForReady(enabled bool, predicate ...string) *Sequence {
+	s.readyWait = enabled
+	s.readyPredicate = ""
+	if len(predicate) > 0 {
+		s.readyPredicate = predicate[0]
+	}
+	return s
+}
+
+// waitForReady polls until the document is complete and the ready predicate (if any) is true
+func (s *Sequence) waitForReady() error {
+	state := ""
+	var predicate interface{}
+
+	timeout := s.clampTimeout(s.EventualTimeout)
+	err := s.driver.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+		if err := s.waitCancelled(); err != nil {
+			return false, err
+		}
+		result, err := d.ExecuteScript("return document.readyState;", nil)
+		if err != nil {
+			return false, nil
+		}
+		state = fmt.Sprintf("%v", result)
+		if state != "complete" {
+			return false, nil
+		}
+		if s.readyPredicate == "" {
+			return true, nil
+		}
+		predicate, err = d.ExecuteScript("return ("+s.readyPredicate+");", nil)
+		if err != nil {
+			predicate = err
+			return false, nil
+		}
+		return predicate == true, nil
+	}, timeout, s.EventualPoll)
+	if err != nil {
+		if s.Context().Err() != nil {
+			return err
+		}
+		if s.readyPredicate != "" && state == "complete" {
+			return fmt.Errorf("The page was not ready within %s. Last readyState: '%s', ready predicate '%s' "+
+				"returned: %v", timeout, state, s.readyPredicate, predicate)
+		}
+		return fmt.Errorf("The page was not ready within %s. Last readyState: '%s'", timeout, state)
+	}
+	return nil
+}
+
+// WaitForURLChange waits until the page's url differs from the passed in url.  If no url is passed in, the
+// current url when the step runs is used.  Waiting is bounded by EventualTimeout and polled every EventualPoll
+func (s *Sequence) WaitForURLChange(from ...string) *Sequence {
+	return s.test("Wait For URL Change", func(d selenium.WebDriver) error {
+		original := ""
+		if len(from) > 0 {
+			original = from[0]
+		} else {
+			var err error
+			original, err = d.CurrentURL()
+			if err != nil {
+				return err
+			}
+		}
+
+		timeout := s.clampTimeout(s.EventualTimeout)
+		err := d.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+			if err := s.waitCancelled(); err != nil {
+				return false, err
+			}
+			uri, err := d.CurrentURL()
+			if err != nil {
+				return false, nil
+			}
+			return uri != original, nil
+		}, timeout, s.EventualPoll)
+		if err != nil {
+			if s.Context().Err() != nil {
+				return err
+			}
+			return fmt.Errorf("The URL never changed from %s within %s", original, timeout)
+		}
+		return nil
+	})
+}
+
+// WaitForURL waits until the matcher returns true for the page's url.  Useful for redirects which pass through
+// intermediate pages.  Waiting is bounded by EventualTimeout and polled every EventualPoll
+func (s *Sequence) WaitForURL(matcher func(u *url.URL) bool) *Sequence {
+	return s.test("Wait For URL", func(d selenium.WebDriver) error {
+		last := ""
+		timeout := s.clampTimeout(s.EventualTimeout)
+		err := d.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+			if err := s.waitCancelled(); err != nil {
+				return false, err
+			}
+			uri, err := d.CurrentURL()
+			if err != nil {
+				return false, nil
+			}
+			last = uri
+			u, err := url.Parse(uri)
+			if err != nil {
+				return false, nil
+			}
+			return matcher(u), nil
+		}, timeout, s.EventualPoll)
+		if err != nil {
+			if s.Context().Err() != nil {
+				return err
+			}
+			return fmt.Errorf("The URL did not match within %s. Last URL: %s", timeout, last)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import "time"
+
+// Reporter receives events as a sequence runs, for building reports of test runs
+type Reporter interface {
+	// StepStarted is called when a step starts running
+	StepStarted(step StepEvent)
+	// StepFinished is called when a step finishes, with the step's error if it failed
+	StepFinished(step StepEvent, err error)
+	// SequenceFinished is called when the sequence is ended with End or Ok, with the sequence's error if it failed
+	SequenceFinished(err error)
+}
+
+// StepEvent describes a step for a Reporter
+type StepEvent struct {
+	Group      string // the names of the groups the step is in, see Sequence.Group
+	Stage      string
+	Step       string // the step's name, see Sequence.Step
+	Selector   string
+	Caller     string
+	Start      time.Time
+	Duration   time.Duration // only set when the step has finished
+	Attempt    int           // zero for the first attempt, then the number of the retry
+	Screenshot string        // path to the screenshot of the step's failure, if one has been taken
+}
+
+// WithReporter adds a reporter which receives events as the sequence runs.  Multiple reporters can be added, and
+// a reporter which panics won't affect the sequence
+func (s *Sequence) WithReporter(r Reporter) *Sequence {
+	s.reporters = append(s.reporters, r)
+	return s
+}
+
+func (s *Sequence) stepEvent(record *stepRecord) StepEvent {
+	event := StepEvent{
+		Group:    record.group,
+		Stage:    record.name,
+		Step:     s.step,
+		Selector: record.selector,
+		Caller:   record.caller,
+		Start:    record.start,
+		Attempt:  record.attempt,
+	}
+	if s.err != nil {
+		event.Screenshot = s.err.Screenshot
+	}
+	return event
+}
+
+func (s *Sequence) reportStarted(record *stepRecord) {
+	if len(s.reporters) == 0 {
+		return
+	}
+	event := s.stepEvent(record)
+	s.report(func(r Reporter) {
+		r.StepStarted(event)
+	})
+}
+
+func (s *Sequence) reportStepFinished(record *stepRecord, duration time.Duration) {
+	if len(s.reporters) == 0 {
+		return
+	}
+	event := s.stepEvent(record)
+	event.Duration = duration
+	var err error
+	if s.err != nil {
+		err = s.err
+	}
+	s.report(func(r Reporter) {
+		r.StepFinished(event, err)
+	})
+}
+
+func (s *Sequence) reportFinished(err error) {
+	s.report(func(r Reporter) {
+		r.SequenceFinished(err)
+	})
+}
+
+// report calls fn with each of the sequence's reporters, recovering from any panics
+func (s *Sequence) report(fn func(r Reporter)) {
+	for i := range s.reporters {
+		func() {
+			defer func() {
+				recover()
+			}()
+			fn(s.reporters[i])
+		}()
+	}
+}
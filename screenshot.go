@@ -0,0 +1,243 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"math"
+
+	"github.com/tebeka/selenium"
+)
+
+// Screenshot takes a screenshot of just the selected element.  The selection must be a single element, and the file
+// name can contain tokens such as {test} and {time}, see Sequence.Screenshot
+func (e *Elements) Screenshot(filename string) *Elements {
+	stage := "Element Screenshot"
+	if e.seq.failed() {
+		return e
+	}
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 1) {
+			return e
+		}
+		if len(e.elems) != 1 {
+			e.seq.err = &Error{
+				Stage:    stage,
+				Selector: e.selector,
+				Err: fmt.Errorf("Selector '%s' matched %d elements, element screenshots need exactly one",
+					e.selector, len(e.elems)),
+				Caller: e.seq.caller(1),
+			}
+			return e
+		}
+		buff, err := e.seq.elementScreenshot(e.elems[0])
+		if err != nil {
+			e.seq.err = &Error{
+				Stage:       stage,
+				Element:     e.elems[0],
+				ElementHTML: e.seq.elementHTML(e.elems[0]),
+				Selector:    e.selector,
+				Err:         err,
+				Caller:      e.seq.caller(1),
+			}
+			return e
+		}
+		name, err := e.seq.filename(filename)
+		if err == nil {
+			err = ioutil.WriteFile(name, buff, 0622)
+		}
+		if err != nil {
+			e.seq.err = &Error{
+				Stage:    "Screenshot Writing File",
+				Selector: e.selector,
+				Err:      err,
+				Caller:   e.seq.caller(1),
+			}
+		}
+		return e
+	}
+	return e.last()
+}
+
+// elementScreenshot returns a PNG of the element, using the driver's element screenshot if it has one, otherwise
+// cropping the element out of a screenshot of the page
+func (s *Sequence) elementScreenshot(we selenium.WebElement) ([]byte, error) {
+	buff, err := we.Screenshot(true)
+	if err == nil {
+		return buff, nil
+	}
+
+	point, err := we.LocationInView()
+	if err != nil {
+		return nil, err
+	}
+	size, err := we.Size()
+	if err != nil {
+		return nil, err
+	}
+	buff, err = s.driver.Screenshot()
+	if err != nil {
+		return nil, err
+	}
+	return cropPNG(buff, image.Rect(point.X, point.Y, point.X+size.Width, point.Y+size.Height))
+}
+
+// cropPNG crops the PNG image to the rectangle
+func cropPNG(buff []byte, rect image.Rectangle) ([]byte, error) {
+	img, err := png.Decode(bytes.NewReader(buff))
+	if err != nil {
+		return nil, fmt.Errorf("Decoding screenshot failed: %s", err)
+	}
+	rect = rect.Intersect(img.Bounds())
+	if rect.Empty() {
+		return nil, fmt.Errorf("Element is outside of the screenshot's bounds %s", img.Bounds())
+	}
+	cropped := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			cropped.Set(x-rect.Min.X, y-rect.Min.Y, img.At(x, y))
+		}
+	}
+	var out bytes.Buffer
+	err = png.Encode(&out, cropped)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+const pageSizeScript = `
+return [
+	Math.max(document.documentElement.scrollHeight, document.body ? document.body.scrollHeight : 0),
+	window.innerHeight,
+	window.pageXOffset,
+	window.pageYOffset,
+	window.devicePixelRatio || 1
+];`
+
+const scrollScript = `window.scrollTo(arguments[0], arguments[1]); return window.pageYOffset;`
+
+// FullPageHeaderCrop sets how many pixels are cropped from the top of every part of a full page screenshot after
+// the first, so headers with a fixed position aren't repeated down the page
+func (s *Sequence) FullPageHeaderCrop(px int) *Sequence {
+	s.headerCrop = px
+	return s
+}
+
+// FullPageScreenshot takes a screenshot of the whole page, rather than just the part in the viewport, by scrolling
+// down the page a viewport at a time and stitching the screenshots together.  The page is scrolled back to where it
+// was afterwards, and the file name can contain tokens such as {test} and {time}, see Screenshot
+func (s *Sequence) FullPageScreenshot(filename string) *Sequence {
+	buff, err := s.fullPageScreenshot()
+	if err != nil {
+		s.err = &Error{
+			Stage:  "Full Page Screenshot",
+			Err:    err,
+			Caller: s.caller(1),
+		}
+		return s
+	}
+
+	name, err := s.filename(filename)
+	if err == nil {
+		err = ioutil.WriteFile(name, buff, 0622)
+	}
+	if err != nil {
+		s.err = &Error{
+			Stage:  "Screenshot Writing File",
+			Err:    err,
+			Caller: s.caller(1),
+		}
+	}
+	return s
+}
+
+func (s *Sequence) fullPageScreenshot() ([]byte, error) {
+	result, err := s.driver.ExecuteScript(pageSizeScript, nil)
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := floats(result, 5)
+	if err != nil {
+		return nil, fmt.Errorf("Unexpected page size result: %s", err)
+	}
+	pageHeight, viewHeight, scrollX, scrollY, ratio := sizes[0], sizes[1], sizes[2], sizes[3], sizes[4]
+	if pageHeight <= 0 || viewHeight <= 0 {
+		return nil, fmt.Errorf("The page (%vpx) or viewport (%vpx) has no height", pageHeight, viewHeight)
+	}
+	defer s.driver.ExecuteScript(scrollScript, []interface{}{scrollX, scrollY})
+
+	crop := float64(s.headerCrop)
+	if crop < 0 || crop >= viewHeight {
+		crop = 0
+	}
+
+	var page *image.RGBA
+	covered := 0.0
+	for y := 0.0; covered < pageHeight; y += viewHeight - crop {
+		result, err = s.driver.ExecuteScript(scrollScript, []interface{}{scrollX, y})
+		if err != nil {
+			return nil, err
+		}
+		top, ok := result.(float64)
+		if !ok {
+			return nil, fmt.Errorf("Unexpected scroll position %v", result)
+		}
+		buff, err := s.driver.Screenshot()
+		if err != nil {
+			return nil, err
+		}
+		segment, err := png.Decode(bytes.NewReader(buff))
+		if err != nil {
+			return nil, fmt.Errorf("Decoding screenshot failed: %s", err)
+		}
+		if page == nil {
+			page = image.NewRGBA(image.Rect(0, 0, segment.Bounds().Dx(), int(math.Ceil(pageHeight*ratio))))
+		}
+
+		// only draw the part of the segment which hasn't already been drawn, skipping the header after the first
+		from := covered
+		if y > 0 && top+crop > from {
+			from = top + crop
+		}
+		to := math.Min(top+viewHeight, pageHeight)
+		if to <= from {
+			break
+		}
+		src := image.Pt(segment.Bounds().Min.X, segment.Bounds().Min.Y+int((from-top)*ratio))
+		dest := image.Rect(0, int(from*ratio), page.Bounds().Dx(), int(to*ratio))
+		draw.Draw(page, dest, segment, src, draw.Src)
+		covered = to
+	}
+
+	var out bytes.Buffer
+	err = png.Encode(&out, page)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// floats returns the script result as a slice of n numbers
+func floats(result interface{}, n int) ([]float64, error) {
+	values, ok := result.([]interface{})
+	if !ok || len(values) != n {
+		return nil, fmt.Errorf("expected %d numbers, got %v", n, result)
+	}
+	nums := make([]float64, n)
+	for i := range values {
+		nums[i], ok = values[i].(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected %d numbers, got %v", n, result)
+		}
+	}
+	return nums, nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tebeka/selenium"
+)
+
+// defaultScriptTimeout is WebDriver's default script timeout
+const defaultScriptTimeout = 30 * time.Second
+
+// ScriptMatch is for testing the result of executing javascript on the page
+type ScriptMatch struct {
+	testName string
+	run      func() (interface{}, error)
+	result   interface{}
+	s        *Sequence
+}
+
+// Script executes the javascript on the current page with the passed in arguments.  Use the returned ScriptMatch
+// to run the script and test its result
+func (s *Sequence) Script(js string, args ...interface{}) *ScriptMatch {
+	return &ScriptMatch{
+		testName: "Script",
+		run: func() (interface{}, error) {
+			return s.driver.ExecuteScript(js, args)
+		},
+		s: s,
+	}
+}
+
+// ScriptAsync executes the asynchronous javascript on the current page with the passed in arguments.  The script
+// signals that it is complete by calling the callback passed in as its last argument.  If the script doesn't
+// complete within the timeout, the test fails with the stage "Async Script Timeout".  The driver's script timeout is
+// set for the duration of the script, and restored afterwards to the timeout set with AsyncScriptTimeout, or
+// WebDriver's default of 30 seconds
+func (s *Sequence) ScriptAsync(js string, timeout time.Duration, args ...interface{}) *ScriptMatch {
+	return &ScriptMatch{
+		testName: "Async Script",
+		run: func() (interface{}, error) {
+			err := s.driver.SetAsyncScriptTimeout(timeout)
+			if err != nil {
+				return nil, err
+			}
+			defer s.driver.SetAsyncScriptTimeout(s.asyncScriptTimeout())
+
+			result, err := s.driver.ExecuteScriptAsync(js, args)
+			if err != nil && isScriptTimeout(err) {
+				return nil, &Error{
+					Stage: "Async Script Timeout",
+					Err:   fmt.Errorf("%w, the script did not complete within %s: %w", ErrTimeout, timeout, err),
+				}
+			}
+			return result, err
+		},
+		s: s,
+	}
+}
+
+// AsyncScriptTimeout sets the driver's timeout for asynchronous scripts.  Set it here rather than on the driver
+// directly, so ScriptAsync can restore it after running
+func (s *Sequence) AsyncScriptTimeout(timeout time.Duration) *Sequence {
+	return s.test("Set Async Script Timeout", func(d selenium.WebDriver) error {
+		err := d.SetAsyncScriptTimeout(timeout)
+		if err != nil {
+			return err
+		}
+		s.scriptTimeout = timeout
+		return nil
+	})
+}
+
+// asyncScriptTimeout returns the driver's timeout for asynchronous scripts outside of ScriptAsync
+func (s *Sequence) asyncScriptTimeout() time.Duration {
+	if s.scriptTimeout == 0 {
+		return defaultScriptTimeout
+	}
+	return s.scriptTimeout
+}
+
+func isScriptTimeout(err error) bool {
+	if sErr, ok := err.(*selenium.Error); ok {
+		return sErr.Err == "script timeout" || sErr.Err == "timeout"
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "script timeout") || strings.Contains(msg, "timed out")
+}
+
+func (m *ScriptMatch) test(testName string, fn func() error) *Sequence {
+	stage := m.testName + " " + testName
+	m.s.last = func() *Sequence {
+		if m.s.failed() {
+			return m.s
+		}
+		if m.s.stopped(stage, "", 2) {
+			return m.s
+		}
+		var err error
+		m.result, err = m.run()
+		if err == nil {
+			err = fn()
+		}
+		if err != nil {
+			if sErr, ok := err.(*Error); ok {
+				sErr.Caller = m.s.caller(2)
+				m.s.err = sErr
+				return m.s
+			}
+			m.s.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: m.s.caller(2),
+			}
+		}
+		return m.s
+	}
+	return m.s.last()
+}
+
+// Run runs the script, and only fails if the script itself errors
+func (m *ScriptMatch) Run() *Sequence {
+	return m.test("Run", func() error {
+		return nil
+	})
+}
+
+// Equals tests if the script's result equals the passed in value once both have been converted to their JSON
+// equivalents, so numbers can be compared regardless of their Go type
+func (m *ScriptMatch) Equals(want interface{}) *Sequence {
+	return m.test("Equals", func() error {
+		data, err := json.Marshal(want)
+		if err != nil {
+			return err
+		}
+		var expected interface{}
+		err = json.Unmarshal(data, &expected)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(m.result, expected) {
+			return fmt.Errorf("The script's result does not equal %v. Got %v", want, m.result)
+		}
+		return nil
+	})
+}
+
+// Truthy tests if the script's result is truthy by javascript's rules
+func (m *ScriptMatch) Truthy() *Sequence {
+	return m.test("Truthy", func() error {
+		switch v := m.result.(type) {
+		case nil:
+		case bool:
+			if v {
+				return nil
+			}
+		case float64:
+			if v != 0 {
+				return nil
+			}
+		case string:
+			if v != "" {
+				return nil
+			}
+		default:
+			return nil
+		}
+		return fmt.Errorf("The script's result is not truthy. Got %v", m.result)
+	})
+}
+
+// AsString tests against the script's result as a string.  The test fails if the script doesn't return a string
+func (m *ScriptMatch) AsString() *ValueMatch {
+	return &ValueMatch{
+		testName: m.testName + " Result",
+		value: func() (string, error) {
+			result, err := m.run()
+			if err != nil {
+				return "", err
+			}
+			str, ok := result.(string)
+			if !ok {
+				return "", fmt.Errorf("The script's result is not a string. Got %v", result)
+			}
+			return str, nil
+		},
+		s: m.s,
+	}
+}
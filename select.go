@@ -0,0 +1,137 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+const selectIndexScript = `
+arguments[0].selectedIndex = arguments[1];
+arguments[0].dispatchEvent(new Event("input", {bubbles: true}));
+arguments[0].dispatchEvent(new Event("change", {bubbles: true}));`
+
+// SelectByVisibleText chooses the option with the passed in text from the selected <select> elements
+func (e *Elements) SelectByVisibleText(text string) *Elements {
+	return e.test("Select By Visible Text", func(we selenium.WebElement) error {
+		return e.seq.selectOption(we, fmt.Sprintf("with the text '%s'", text),
+			func(i int, option selenium.WebElement) (bool, error) {
+				optionText, err := option.Text()
+				return optionText == text, err
+			})
+	})
+}
+
+// SelectByValue chooses the option with the passed in value from the selected <select> elements
+func (e *Elements) SelectByValue(value string) *Elements {
+	return e.test("Select By Value", func(we selenium.WebElement) error {
+		return e.seq.selectOption(we, fmt.Sprintf("with the value '%s'", value),
+			func(i int, option selenium.WebElement) (bool, error) {
+				optionValue, err := option.GetAttribute("value")
+				return optionValue == value, err
+			})
+	})
+}
+
+// SelectByIndex chooses the option at the passed in index from the selected <select> elements
+func (e *Elements) SelectByIndex(index int) *Elements {
+	return e.test("Select By Index", func(we selenium.WebElement) error {
+		return e.seq.selectOption(we, fmt.Sprintf("at index %d", index),
+			func(i int, option selenium.WebElement) (bool, error) {
+				return i == index, nil
+			})
+	})
+}
+
+// selectOption clicks the first option in the select element for which match returns true.  If clicking the
+// option fails, the option is selected via javascript instead
+func (s *Sequence) selectOption(we selenium.WebElement, description string,
+	match func(i int, option selenium.WebElement) (bool, error)) error {
+	tag, err := we.TagName()
+	if err != nil {
+		return err
+	}
+	if tag != "select" {
+		return fmt.Errorf("Element is a <%s> not a <select>", tag)
+	}
+
+	options, err := we.FindElements(selenium.ByCSSSelector, "option")
+	if err != nil {
+		return err
+	}
+
+	for i := range options {
+		ok, err := match(i, options[i])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		err = options[i].Click()
+		if err != nil {
+			_, err = s.driver.ExecuteScript(selectIndexScript, []interface{}{we, i})
+		}
+		return err
+	}
+
+	available := ""
+	for i := range options {
+		text, _ := options[i].Text()
+		value, _ := options[i].GetAttribute("value")
+		available += fmt.Sprintf("\n\t%d: '%s' (value '%s')", i, text, value)
+	}
+	return fmt.Errorf("No option exists %s. Available options: %s", description, available)
+}
+
+// SelectedOption returns a new Elements containing the currently selected <option> of each of the selected
+// <select> elements.  Multi-select elements include all of their selected options
+func (e *Elements) SelectedOption() *Elements {
+	return e.traverse("Selected Option", fmt.Sprintf("%s option:checked", e.selector),
+		func(elems []selenium.WebElement) ([]selenium.WebElement, error) {
+			var found []selenium.WebElement
+			for i := range elems {
+				options, err := selectChildren(elems[i], "option:checked")
+				if err != nil {
+					return nil, err
+				}
+				if len(options) == 0 {
+					return nil, fmt.Errorf("Element %s has no selected option", elementString(elems[i]))
+				}
+				found = append(found, options...)
+			}
+			return found, nil
+		})
+}
+
+// Options returns a new Elements containing all of the <option> elements of each of the selected <select>
+// elements
+func (e *Elements) Options() *Elements {
+	return e.traverse("Options", fmt.Sprintf("%s option", e.selector),
+		func(elems []selenium.WebElement) ([]selenium.WebElement, error) {
+			var found []selenium.WebElement
+			for i := range elems {
+				options, err := selectChildren(elems[i], "option")
+				if err != nil {
+					return nil, err
+				}
+				found = append(found, options...)
+			}
+			return found, nil
+		})
+}
+
+// selectChildren finds the children of a <select> element matching the selector, and errors if the element
+// is not a <select>
+func selectChildren(we selenium.WebElement, selector string) ([]selenium.WebElement, error) {
+	tag, err := we.TagName()
+	if err != nil {
+		return nil, err
+	}
+	if tag != "select" {
+		return nil, fmt.Errorf("Element %s is a <%s> not a <select>", elementString(we), tag)
+	}
+	return we.FindElements(selenium.ByCSSSelector, selector)
+}
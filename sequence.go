@@ -3,8 +3,12 @@
 package sequence
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"path/filepath"
@@ -13,28 +17,88 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/tebeka/selenium"
+	"github.com/tebeka/selenium/log"
 )
 
 // Sequence is a helper structs of chaining selecting elements and testing them
 // if any part of the sequence fails the sequence ends and returns the error
 // built to make writing tests easier
 type Sequence struct {
-	driver          selenium.WebDriver
-	err             *Error
-	EventualPoll    time.Duration
-	EventualTimeout time.Duration
-	last            func() *Sequence
-	onErr           func(Error, *Sequence)
+	driver           selenium.WebDriver
+	err              *Error
+	EventualPoll     time.Duration
+	EventualTimeout  time.Duration
+	DragMethod       DragMethod
+	RemoteURL        string // url of the remote WebDriver server the driver is connected to, see Start
+	last             func() *Sequence
+	onErr            []func(Error, *Sequence)
+	redacted         []string
+	frames           []selenium.WebElement
+	windows          []string
+	logs             []log.Message
+	ignoreJSErrors   []*regexp.Regexp
+	readyWait        bool
+	readyPredicate   string
+	backoff          *backoff
+	deadline         time.Time
+	ctx              context.Context
+	retryStale       bool
+	soft             bool
+	softErrs         []*Error
+	errorHTMLLimit   int
+	screenshotDir    string
+	artifactsDir     string
+	artifactsWritten bool
+	testName         string
+	step             string
+	verbose          io.Writer
+	current          *stepRecord
+	attempt          int
+	recordTimings    bool
+	timings          []StepTiming
+	reporters        []Reporter
+	groups           []string
+	callerSkip       int
+	htmlMatchLimit   int
+	headerCrop       int
+	output           io.Writer
+	fileCount        int
+	scopes           []scope
+	scriptTimeout    time.Duration
+	filtering        bool // the sequence only runs a Filter's tests, its errors never reach the real sequence
+}
+
+// backoff is a policy for increasing the delay between Eventually's retries
+type backoff struct {
+	initial time.Duration
+	max     time.Duration
+	factor  float64
 }
 
 // Error describes an error that occured during the sequence processing.
 type Error struct {
-	Stage   string
-	Element selenium.WebElement
-	Err     error
-	Caller  string
+	Stage       string
+	Step        string // the name of the step the error occurred in, see Sequence.Step
+	Group       string // the names of the groups the error occurred in, see Sequence.Group
+	Element     selenium.WebElement
+	ElementHTML string // the element's outer HTML when the error occurred, truncated to the sequence's ErrorHTMLLimit
+	Selector    string
+	Err         error
+	Caller      string
+	Screenshot  string // path to the screenshot taken when the error occurred, see Sequence.ScreenshotOnError
+	Artifacts   string // path to the directory of failure artifacts, see Sequence.ArtifactsOnError
+
+	redacted      []string
+	timestamp     time.Time
+	url           string
+	element       string
+	screenshotErr error
+	artifactsErr  error
+	handlerPanics []string
+	scoped        bool
 }
 
 // caller returns the caller (file and line number) of the function from the perspective of where this caller function
@@ -54,13 +118,107 @@ func caller(skip int) string {
 	return fmt.Sprintf("%s:%d", file, line)
 }
 
+// caller returns the caller the same as the caller function, with any extra frames added by AddCallerSkip skipped
+func (s *Sequence) caller(skip int) string {
+	return caller(skip + 1 + s.callerSkip)
+}
+
+// AddCallerSkip adds n extra frames to skip when recording where errors in the sequence occurred, so errors from
+// steps in wrapper functions point at the code calling the wrapper.  The skip is reset when the Use or Group it was
+// added in returns.  In a plain function, defer the removal of the skip:
+//
+//	func mustClick(s *sequence.Sequence, selector string) *sequence.Sequence {
+//		defer s.AddCallerSkip(1).AddCallerSkip(-1)
+//		return s.Find(selector).Click()
+//	}
+func (s *Sequence) AddCallerSkip(n int) *Sequence {
+	s.callerSkip += n
+	return s
+}
+
+// AddCallerSkip adds n extra frames to skip when recording where errors occurred, see Sequence.AddCallerSkip
+func (e *Elements) AddCallerSkip(n int) *Elements {
+	e.seq.AddCallerSkip(n)
+	return e
+}
+
 // Error fulfills the error interface
 func (e *Error) Error() string {
-	if e.Element != nil {
-		return fmt.Sprintf("An error occurred at %s during %s on element %s: %s", e.Caller, e.Stage,
+	stage := e.Stage
+	if e.Step != "" {
+		stage += " [" + e.Step + "]"
+	}
+	var str string
+	switch {
+	case e.ElementHTML != "":
+		str = fmt.Sprintf("An error occurred at %s during %s on element %s: %s", e.Caller, stage, e.ElementHTML,
+			e.Err)
+	case e.Element != nil:
+		str = fmt.Sprintf("An error occurred at %s during %s on element %s: %s", e.Caller, stage,
 			elementString(e.Element), e.Err)
+	default:
+		str = fmt.Sprintf("An error occurred at %s during %s:  %s", e.Caller, stage, e.Err)
+	}
+	for i := range e.handlerPanics {
+		str += "\n\t" + e.handlerPanics[i]
+	}
+	return redact(str, e.redacted)
+}
+
+// Unwrap returns the underlying error, for use with errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON implements json.Marshaler, for machine readable test output
+func (e *Error) MarshalJSON() ([]byte, error) {
+	msg := ""
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	element := e.element
+	if element == "" {
+		element = e.ElementHTML
 	}
-	return fmt.Sprintf("An error occurred at %s during %s:  %s", e.Caller, e.Stage, e.Err)
+	var timestamp *time.Time
+	if !e.timestamp.IsZero() {
+		timestamp = &e.timestamp
+	}
+	return json.Marshal(struct {
+		Stage      string     `json:"stage"`
+		Step       string     `json:"step,omitempty"`
+		Group      string     `json:"group,omitempty"`
+		Caller     string     `json:"caller"`
+		Selector   string     `json:"selector,omitempty"`
+		Element    string     `json:"element,omitempty"`
+		Error      string     `json:"error"`
+		Timestamp  *time.Time `json:"timestamp,omitempty"`
+		URL        string     `json:"url,omitempty"`
+		Screenshot string     `json:"screenshot,omitempty"`
+		Artifacts  string     `json:"artifacts,omitempty"`
+	}{
+		Stage:      e.Stage,
+		Step:       e.Step,
+		Group:      e.Group,
+		Caller:     e.Caller,
+		Selector:   e.Selector,
+		Element:    redact(element, e.redacted),
+		Error:      redact(msg, e.redacted),
+		Timestamp:  timestamp,
+		URL:        redact(e.url, e.redacted),
+		Screenshot: e.Screenshot,
+		Artifacts:  e.Artifacts,
+	})
+}
+
+// redact replaces any of the redacted values in str with asterisks
+func redact(str string, redacted []string) string {
+	for i := range redacted {
+		if redacted[i] != "" {
+			str = strings.Replace(str, redacted[i], "********", -1)
+		}
+	}
+	return str
 }
 
 // Errors is multiple sequence errors
@@ -74,6 +232,103 @@ func (e Errors) Error() string {
 	return str
 }
 
+// Unwrap returns the individual errors, for use with errors.Is and errors.As
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// MarshalJSON implements json.Marshaler as an array of the individual errors
+func (e Errors) MarshalJSON() ([]byte, error) {
+	errs := make([]interface{}, len(e))
+	for i := range e {
+		if _, ok := e[i].(json.Marshaler); ok {
+			errs[i] = e[i]
+			continue
+		}
+		errs[i] = struct {
+			Error string `json:"error"`
+		}{
+			Error: e[i].Error(),
+		}
+	}
+	return json.Marshal(errs)
+}
+
+var (
+	// ErrNoElements is wrapped when a test runs against a selector which matched no elements
+	ErrNoElements = errors.New("No elements exist")
+	// ErrMultipleElementsWithoutQuantifier is wrapped when a test runs against multiple elements without
+	// specifying Any, All or None
+	ErrMultipleElementsWithoutQuantifier = errors.New(
+		"returned multiple elements but .Any(), .All() or .None() weren't specified")
+	// ErrTimeout is wrapped when Eventually or another wait times out
+	ErrTimeout = errors.New("Timed out")
+)
+
+// containsFold reports whether substr is within s under Unicode case folding
+func containsFold(s, substr string) bool {
+	n := utf8.RuneCountInString(substr)
+	if n == 0 {
+		return true
+	}
+	for i := range s {
+		rest := s[i:]
+		end := len(rest)
+		count := 0
+		for j := range rest {
+			if count == n {
+				end = j
+				break
+			}
+			count++
+		}
+		if count < n {
+			return false
+		}
+		if strings.EqualFold(rest[:end], substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultErrorHTMLLimit is how many characters of an element's HTML are included in errors by default
+const defaultErrorHTMLLimit = 500
+
+// ErrorHTMLLimit sets how many characters of a failing element's outer HTML are included in errors.  Set to 0 to
+// leave the HTML out of errors
+func (s *Sequence) ErrorHTMLLimit(n int) *Sequence {
+	s.errorHTMLLimit = n
+	return s
+}
+
+// HTMLMatchLimit sets how many characters of an element's HTML are shown in InnerHTML and OuterHTML failures.
+// Longer HTML is shown from around where it first differs from the expected value.  Set to 0 to show all of it
+func (s *Sequence) HTMLMatchLimit(n int) *Sequence {
+	s.htmlMatchLimit = n
+	return s
+}
+
+// elementHTML returns the element's outer HTML truncated to the ErrorHTMLLimit, or an empty string if it can't be
+// retrieved.  It's captured when the error occurs, as the element may no longer exist by the time the error is output
+func (s *Sequence) elementHTML(element selenium.WebElement) string {
+	if element == nil || s.errorHTMLLimit <= 0 {
+		return ""
+	}
+	html, err := element.GetAttribute("outerHTML")
+	if err != nil {
+		return ""
+	}
+	if len(html) <= s.errorHTMLLimit {
+		return html
+	}
+	end := s.errorHTMLLimit
+	for end > 0 && !utf8.RuneStart(html[end]) {
+		end--
+	}
+	return html[:end] + "..."
+}
+
 func elementString(element selenium.WebElement) string {
 	if element == nil {
 		return ""
@@ -107,49 +362,256 @@ type Elements struct {
 	last       func() *Elements
 	all        bool
 	any        bool
+	none       bool
+	filters    []*filterResult
 }
 
-// Start starts a new sequence of tests
+// Start starts a new sequence of tests.  If the driver is connected to a remote WebDriver server, such as a Selenium
+// grid, set the sequence's RemoteURL to the same url passed to selenium.NewRemote, so that UploadFile can push files
+// to the server.  Pushing files uses selenium.HTTPClient, the same http client the driver uses
 func Start(driver selenium.WebDriver) *Sequence {
+	return StartWithContext(context.Background(), driver)
+}
+
+// StartWithContext starts a new sequence of tests which stops running steps once the passed in context is cancelled
+func StartWithContext(ctx context.Context, driver selenium.WebDriver) *Sequence {
 	return &Sequence{
 		driver:          driver,
 		EventualPoll:    100 * time.Millisecond,
 		EventualTimeout: 60 * time.Second,
+		ctx:             ctx,
+		errorHTMLLimit:  defaultErrorHTMLLimit,
+		htmlMatchLimit:  defaultErrorHTMLLimit,
 	}
 }
 
-// End ends a sequence and returns any errors
+// End ends a sequence and returns any errors.  In soft mode, all of the collected errors are returned as Errors
 func (s *Sequence) End() error {
-	if s.err != nil {
-		if s.onErr != nil {
-			s.onErr(*s.err, s)
-		}
+	return s.endErr(s.finish())
+}
+
+// endErr returns the error for the sequence from all of its errors
+func (s *Sequence) endErr(errs []*Error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(s.softErrs) == 0 {
 		return s.err
 	}
-	return nil
+	result := make(Errors, len(errs))
+	for i := range errs {
+		result[i] = errs[i]
+	}
+	return result
 }
 
-// OK ends a sequence and fails and stopped the tests passed in if the sequence is in error
+// OK ends a sequence and fails and stopped the tests passed in if the sequence is in error.  In soft mode, each
+// collected error is reported before the test is stopped.  If no output has been set, anything printed while
+// ending, such as by an error handler calling Debug, is logged to the test
 func (s *Sequence) Ok(tb testing.TB) {
-	if s.err != nil {
-		if s.onErr != nil {
-			s.onErr(*s.err, s)
+	tb.Helper()
+	s.testName = tb.Name()
+	if s.output == nil {
+		s.output = tbWriter{tb}
+	}
+	errs := s.finish()
+	if len(errs) == 0 {
+		return
+	}
+	for i := range errs {
+		if errs[i].Screenshot != "" {
+			tb.Logf("Screenshot of failure saved to %s", errs[i].Screenshot)
+		}
+		if errs[i].screenshotErr != nil {
+			tb.Logf("Screenshot of failure could not be saved: %s", errs[i].screenshotErr)
+		}
+		if errs[i].Artifacts != "" {
+			tb.Logf("Failure artifacts saved to %s", errs[i].Artifacts)
+		}
+		if errs[i].artifactsErr != nil {
+			tb.Logf("Failure artifacts could not be saved: %s", errs[i].artifactsErr)
 		}
+	}
+	for i := range errs {
+		if errs[i].url != "" {
+			tb.Errorf("Sequence failed: %s\n\tURL: %s", errs[i], redact(errs[i].url, s.redacted))
+			continue
+		}
+		tb.Errorf("Sequence failed: %s", errs[i])
+	}
+	tb.FailNow()
+}
+
+// finish returns all of the errors in the sequence, and calls the OnError handler for each of them
+func (s *Sequence) finish() []*Error {
+	s.endStep()
+	errs := append([]*Error{}, s.softErrs...)
+	if s.err != nil {
+		errs = append(errs, s.err)
+	}
+	for i := range errs {
+		s.details(errs[i])
+		s.handleError(errs[i])
+	}
+	s.reportFinished(s.endErr(errs))
+	return errs
+}
+
+// hardStages are the stages whose errors stop the sequence even in soft mode, because the steps after them can't
+// sensibly run
+var hardStages = map[string]bool{
+	"Get":                        true,
+	"Get And Wait":               true,
+	"Get With Retry":             true,
+	"Elements":                   true,
+	"Context Cancelled":          true,
+	"Sequence Deadline Exceeded": true,
+}
+
+// Soft switches the sequence into soft mode, where failed tests are collected and the sequence carries on, so
+// every failure can be reported at once by End or Ok.  Failed navigation still stops the sequence
+func (s *Sequence) Soft() *Sequence {
+	s.soft = true
+	return s
+}
+
+// Hard switches the sequence back to stopping at the first failure, which is the default
+func (s *Sequence) Hard() *Sequence {
+	s.failed()
+	s.soft = false
+	return s
+}
+
+// failed returns whether the sequence has failed and the next step shouldn't run.  In soft mode, the error from the
+// previous step is collected instead, unless it's from a stage the sequence can't continue after
+func (s *Sequence) failed() bool {
+	s.endStep()
+	if s.err == nil {
+		return false
+	}
+	if !s.soft || hardStages[ungroupedStage(s.err)] {
+		return true
+	}
+	s.details(s.err)
+	s.softErrs = append(s.softErrs, s.err)
+	s.err = nil
+	return false
+}
+
+// endStep ends the step which just ran.  If it failed, the error's details and screenshot are recorded before the
+// step is reported as finished, so reporters can link to them
+func (s *Sequence) endStep() {
+	if s.err != nil {
+		s.details(s.err)
+	}
+	s.logEnd()
+}
+
+// details records when the error was seen by the sequence, along with the page and element it occurred on, so they
+// are available after the page has moved on
+func (s *Sequence) details(err *Error) {
+	if s.filtering {
+		return
+	}
+	err.redacted = s.redacted
+	if !err.timestamp.IsZero() {
+		return
+	}
+	err.timestamp = time.Now()
+	if err.Step == "" {
+		err.Step = s.step
+	}
+	s.tagGroup(err)
+	if url, uErr := s.driver.CurrentURL(); uErr == nil {
+		err.url = url
+	}
+	err.element = err.ElementHTML
+	if err.element == "" {
+		err.element = elementString(err.Element)
+	}
+	s.errorScreenshot(err)
+	s.errorArtifacts(err)
+}
 
-		fmt.Printf("Sequence failed: %s", s.err)
-		tb.FailNow()
+// ErrorDetails returns the error the sequence stopped on, or in soft mode the most recent error collected, or nil
+// if the sequence hasn't failed
+func (s *Sequence) ErrorDetails() *Error {
+	err := s.err
+	if err == nil && len(s.softErrs) > 0 {
+		err = s.softErrs[len(s.softErrs)-1]
+	}
+	if err == nil {
+		return nil
 	}
+	s.details(err)
+	return err
 }
 
 // OnError registers a function to call when an error occurs in the sequence.
 // Handy for calling things like .Debug() and .Screenshot("err.png") in error scenarios to output to
 // a CI server
 // OnError must be called before any errors in order for it to be triggered properly
+// Multiple handlers can be registered, and are called in the order they were registered
 func (s *Sequence) OnError(fn func(err Error, s *Sequence)) *Sequence {
-	s.onErr = fn
+	s.onErr = append(s.onErr, fn)
+	return s
+}
+
+// ClearErrorHandlers removes all of the handlers registered with OnError
+func (s *Sequence) ClearErrorHandlers() *Sequence {
+	s.onErr = nil
+	return s
+}
+
+// handleError calls each of the registered error handlers with the passed in error.  A panic in one handler doesn't
+// stop the rest from running, and is reported on the error, and the sequence's error is kept even if a handler
+// runs steps which fail
+func (s *Sequence) handleError(err *Error) {
+	original := s.err
+	for i := range s.onErr {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err.handlerPanics = append(err.handlerPanics, fmt.Sprintf("Error handler %d panicked: %v", i+1, r))
+				}
+			}()
+			s.onErr[i](*err, s)
+		}()
+	}
+	s.err = original
+}
+
+// RedactValue registers a secret value which will be replaced with asterisks anywhere it would otherwise be
+// output by the sequence, such as in error messages and debug output
+func (s *Sequence) RedactValue(v string) *Sequence {
+	s.redacted = append(s.redacted, v)
+	return s
+}
+
+// Context returns the sequence's context, so custom tests can stop when it is cancelled
+func (s *Sequence) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// Step names the steps which follow it, up until the next call to Step.  The name is included in any errors from
+// those steps, so failures can be understood without looking up the test's code
+func (s *Sequence) Step(name string) *Sequence {
+	if s.failed() {
+		return s
+	}
+	s.step = name
 	return s
 }
 
+// Step names the steps which follow it, see Sequence.Step
+func (e *Elements) Step(name string) *Elements {
+	e.seq.Step(name)
+	return e
+}
+
 // Driver returns the underlying WebDriver
 func (s *Sequence) Driver() selenium.WebDriver {
 	return s.driver
@@ -158,108 +620,515 @@ func (s *Sequence) Driver() selenium.WebDriver {
 // Eventually will retry the previous test if it returns an error every EventuallyPoll duration until EventualTimeout
 // is reached
 func (s *Sequence) Eventually() *Sequence {
+	return s.eventually(s.EventualTimeout, s.EventualPoll, s.backoff)
+}
+
+// EventuallyFor will retry the previous test if it returns an error every poll duration until the timeout is
+// reached, without changing the sequence's EventualTimeout and EventualPoll
+func (s *Sequence) EventuallyFor(timeout, poll time.Duration) *Sequence {
+	return s.eventually(timeout, poll, nil)
+}
+
+// Deadline sets a deadline for the entire sequence of the passed in duration from now.  Any step run after the
+// deadline fails, and Eventually won't retry past the deadline
+func (s *Sequence) Deadline(d time.Duration) *Sequence {
+	s.deadline = time.Now().Add(d)
+	return s
+}
+
+// stopped returns true and sets the sequence's error if the sequence's context has been cancelled, or the sequence
+// has passed its deadline, before running the passed in step against the selector, if any.  skip is passed to caller
+// from the perspective of the function calling stopped
+func (s *Sequence) stopped(step, selector string, skip int) bool {
+	if s.tracking() {
+		s.logStart(step, selector, s.caller(skip+1))
+	}
+	if selector != "" {
+		step += " '" + selector + "'"
+	}
+	if err := s.stopErr(step); err != nil {
+		err.Caller = s.caller(skip + 1)
+		s.err = err
+		return true
+	}
+	return false
+}
+
+// stopErr returns an error if the sequence's context has been cancelled, or the sequence has passed its deadline,
+// before running the passed in step
+func (s *Sequence) stopErr(step string) *Error {
+	if err := s.contextErr(step); err != nil {
+		return err
+	}
+	if s.deadline.IsZero() {
+		return nil
+	}
+	over := time.Since(s.deadline)
+	if over < 0 {
+		return nil
+	}
+	return &Error{
+		Stage: "Sequence Deadline Exceeded",
+		Err: fmt.Errorf("The sequence was %s over its deadline before running %s", over.Round(time.Millisecond),
+			step),
+	}
+}
+
+// consistentlyWait waits for the poll between retries of Consistently, returning an error instead if the sequence's
+// context is cancelled or its deadline is reached
+func (s *Sequence) consistentlyWait(step string) *Error {
+	select {
+	case <-s.Context().Done():
+	case <-time.After(s.clampTimeout(s.EventualPoll)):
+	}
+	return s.stopErr(step)
+}
+
+// contextErr returns an error if the sequence's context has been cancelled
+func (s *Sequence) contextErr(step string) *Error {
+	if s.ctx == nil || s.ctx.Err() == nil {
+		return nil
+	}
+	return &Error{
+		Stage: "Context Cancelled",
+		Err:   fmt.Errorf("The sequence's context was cancelled before running %s: %s", step, s.ctx.Err()),
+	}
+}
+
+// EventualBackoff makes Eventually wait the initial duration before its first retry, and multiply the wait by factor
+// after each retry up to the max duration, instead of retrying every EventualPoll
+func (s *Sequence) EventualBackoff(initial, max time.Duration, factor float64) *Sequence {
+	s.backoff = &backoff{
+		initial: initial,
+		max:     max,
+		factor:  factor,
+	}
+	return s
+}
+
+// waitCancelled returns an error if the sequence's context has been cancelled, for stopping polls which wait on the
+// driver early
+func (s *Sequence) waitCancelled() error {
+	if err := s.Context().Err(); err != nil {
+		return fmt.Errorf("The sequence's context was cancelled while waiting: %w", err)
+	}
+	return nil
+}
+
+// clampTimeout returns the timeout shortened to the time left before the sequence's deadline, if it has one
+func (s *Sequence) clampTimeout(timeout time.Duration) time.Duration {
+	if s.deadline.IsZero() {
+		return timeout
+	}
+	if remaining := time.Until(s.deadline); remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
+
+// wait calls condition until it returns true or the timeout is reached, and returns the number of attempts made.
+// If a backoff policy is passed in it's used for the delay between attempts, otherwise it polls at a fixed interval
+func (s *Sequence) wait(condition selenium.Condition, timeout, poll time.Duration, b *backoff) (int, error) {
+	attempts := 0
+	counted := func(d selenium.WebDriver) (bool, error) {
+		if err := s.contextErr("Eventually"); err != nil {
+			return false, err
+		}
+		s.logEnd()
+		attempts++
+		s.attempt = attempts
+		defer s.logEnd()
+		return condition(d)
+	}
+	defer func() {
+		s.attempt = 0
+	}()
+	timeout = s.clampTimeout(timeout)
+	if b == nil {
+		return attempts, s.driver.WaitWithTimeoutAndInterval(counted, timeout, poll)
+	}
+
+	start := time.Now()
+	delay := b.initial
+	for {
+		done, err := counted(s.driver)
+		if err != nil {
+			return attempts, err
+		}
+		if done {
+			return attempts, nil
+		}
+		if elapsed := time.Since(start); elapsed > timeout {
+			return attempts, fmt.Errorf("timeout after %v", elapsed)
+		}
+		select {
+		case <-s.Context().Done():
+		case <-time.After(delay):
+		}
+		delay = time.Duration(float64(delay) * b.factor)
+		if delay > b.max {
+			delay = b.max
+		}
+	}
+}
+
+func (s *Sequence) eventually(timeout, poll time.Duration, b *backoff) *Sequence {
 	if s.err == nil {
 		return s
 	}
 
-	err := s.driver.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+	lastErr := s.err
+	attempts, err := s.wait(func(d selenium.WebDriver) (bool, error) {
 		s.err = nil
 		s = s.last()
 		if s.err != nil {
+			lastErr = s.err
 			return false, nil
 		}
 		return true, nil
-	}, s.EventualTimeout, s.EventualPoll)
+	}, timeout, poll, b)
+	if cErr, ok := err.(*Error); ok {
+		s.err = cErr
+		s.err.Caller = s.caller(1)
+		return s
+	}
 	if err != nil {
-		s.err.Caller = caller(0)
+		if s.err == nil {
+			s.err = timeoutErr(err, lastErr)
+		}
+		s.err.Err = fmt.Errorf("%w after %s and %d attempts: %w", ErrTimeout, timeout, attempts, s.err.Err)
+		s.err.Caller = s.caller(1)
 	}
 	return s
 }
 
+// timeoutErr builds the error for an Eventually which timed out without the retried step leaving an error, wrapping
+// the driver's error and the last error captured from the step, if any
+func timeoutErr(waitErr error, lastErr *Error) *Error {
+	if lastErr == nil {
+		return &Error{
+			Stage: "Eventually Timeout",
+			Err:   waitErr,
+		}
+	}
+	return &Error{
+		Stage:       "Eventually Timeout",
+		Element:     lastErr.Element,
+		ElementHTML: lastErr.ElementHTML,
+		Err:         fmt.Errorf("%w. Last error during %s: %w", waitErr, lastErr.Stage, lastErr.Err),
+	}
+}
+
 // Eventually will retry the previous test if it returns an error every EventuallyPoll duration until EventualTimeout
 // is reached
 func (e *Elements) Eventually() *Elements {
-	if e.seq.err == nil {
+	return e.eventually(e.seq.EventualTimeout, e.seq.EventualPoll, e.seq.backoff)
+}
+
+// EventuallyFor will re-select the elements and retry the previous test if it returns an error every poll duration
+// until the timeout is reached, without changing the sequence's EventualTimeout and EventualPoll
+func (e *Elements) EventuallyFor(timeout, poll time.Duration) *Elements {
+	return e.eventually(timeout, poll, nil)
+}
+
+func (e *Elements) eventually(timeout, poll time.Duration, b *backoff) *Elements {
+	// elements selected after the sequence had already failed have no step to retry
+	if e.seq.err == nil || e.last == nil {
 		return e
 	}
 
 	if e.selectFunc == nil || e.selector == "" {
+		e.seq.err = &Error{
+			Stage:       "Eventually",
+			Element:     e.seq.err.Element,
+			ElementHTML: e.seq.err.ElementHTML,
+			Err: fmt.Errorf("cannot retry: no selector available. Error during %s: %s", e.seq.err.Stage,
+				e.seq.err.Err),
+			Caller: e.seq.caller(1),
+		}
 		return e
 	}
 
-	err := e.seq.driver.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+	lastErr := e.seq.err
+	attempts, err := e.seq.wait(func(d selenium.WebDriver) (bool, error) {
 		e.seq.err = nil
 		var err error
 		e.elems, err = e.selectFunc(e.selector)
 		if err != nil {
 			e.seq.err = &Error{
-				Stage:  "Elements",
-				Err:    err,
-				Caller: caller(1),
+				Stage:    "Elements",
+				Selector: e.selector,
+				Err:      err,
+				Caller:   e.seq.caller(1),
 			}
+			lastErr = e.seq.err
 			return false, nil
 		}
 		e = e.last()
 		if e.seq.err != nil {
+			lastErr = e.seq.err
 			return false, nil
 		}
 		return true, nil
-	}, e.seq.EventualTimeout, e.seq.EventualPoll)
+	}, timeout, poll, b)
+	if cErr, ok := err.(*Error); ok {
+		e.seq.err = cErr
+		e.seq.err.Caller = e.seq.caller(1)
+		return e
+	}
 	if err != nil {
-		e.seq.err.Caller = caller(0)
+		if e.seq.err == nil {
+			e.seq.err = timeoutErr(err, lastErr)
+		}
+		e.seq.err.Err = fmt.Errorf("%w after %s and %d attempts: %w", ErrTimeout, timeout, attempts, e.seq.err.Err)
+		e.seq.err.Caller = e.seq.caller(1)
 	}
 	return e
 }
 
-// Test runs an arbitrary test against the entire page
-func (s *Sequence) Test(testName string, fn func(d selenium.WebDriver) error) *Sequence {
-	if s.err != nil {
+// EventuallyGroup runs all of the steps in the passed in function, and retries all of them together every
+// EventualPoll until they complete without error or EventualTimeout is reached
+func (s *Sequence) EventuallyGroup(fn func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
 		return s
 	}
-	s = s.test(testName, fn)
-	if s.err != nil {
-		s.err.Caller = caller(0)
+
+	group := func() *Sequence {
+		fn(s)
+		return s
 	}
-	return s
-}
 
-func (s *Sequence) test(testName string, fn func(d selenium.WebDriver) error) *Sequence {
-	s.last = func() *Sequence {
+	var lastErr *Error
+	attempts, err := s.wait(func(d selenium.WebDriver) (bool, error) {
+		s.err = nil
+		group()
 		if s.err != nil {
-			return s
+			lastErr = s.err
+			return false, nil
+		}
+		return true, nil
+	}, s.EventualTimeout, s.EventualPoll, s.backoff)
+	s.last = group
+	if cErr, ok := err.(*Error); ok {
+		s.err = cErr
+		s.err.Caller = s.caller(0)
+		return s
+	}
+	if err != nil {
+		s.err = groupErr(lastErr, err, attempts)
+		if lastErr == nil {
+			s.err.Caller = s.caller(0)
 		}
+	}
+	return s
+}
 
-		err := fn(s.driver)
+// EventuallyGroup runs all of the steps in the passed in function against the selected elements, and re-selects the
+// elements and retries all of the steps together every EventualPoll until they complete without error or
+// EventualTimeout is reached
+func (e *Elements) EventuallyGroup(fn func(e *Elements) *Elements) *Elements {
+	if e.seq.failed() {
+		return e
+	}
 
-		if err != nil {
-			s.err = &Error{
-				Stage:  testName,
-				Err:    err,
-				Caller: caller(2),
+	group := func() *Elements {
+		fn(e)
+		return e
+	}
+
+	first := true
+	var lastErr *Error
+	attempts, err := e.seq.wait(func(d selenium.WebDriver) (bool, error) {
+		e.seq.err = nil
+		if first {
+			first = false
+		} else if e.selectFunc != nil && e.selector != "" {
+			var err error
+			e.elems, err = e.selectFunc(e.selector)
+			if err != nil {
+				lastErr = &Error{
+					Stage:    "Elements",
+					Selector: e.selector,
+					Err:      err,
+					Caller:   e.seq.caller(1),
+				}
+				e.seq.err = lastErr
+				return false, nil
 			}
 		}
-		return s
+		group()
+		if e.seq.err != nil {
+			lastErr = e.seq.err
+			return false, nil
+		}
+		return true, nil
+	}, e.seq.EventualTimeout, e.seq.EventualPoll, e.seq.backoff)
+	e.last = group
+	if cErr, ok := err.(*Error); ok {
+		e.seq.err = cErr
+		e.seq.err.Caller = e.seq.caller(0)
+		return e
 	}
-	return s.last()
+	if err != nil {
+		e.seq.err = groupErr(lastErr, err, attempts)
+		if lastErr == nil {
+			e.seq.err.Caller = e.seq.caller(0)
+		}
+	}
+	return e
 }
 
-// TitleMatch is for testing the value of the title
-type TitleMatch struct {
-	title string
-	s     *Sequence
+// groupErr builds the error for a group which never completed, from the error of its last failed attempt
+func groupErr(lastErr *Error, waitErr error, attempts int) *Error {
+	if lastErr == nil {
+		return &Error{
+			Stage: "Eventually Group",
+			Err:   fmt.Errorf("%w, the group did not complete after %d attempts: %w", ErrTimeout, attempts, waitErr),
+		}
+	}
+	return &Error{
+		Stage:       "Eventually Group " + lastErr.Stage,
+		Element:     lastErr.Element,
+		ElementHTML: lastErr.ElementHTML,
+		Err: fmt.Errorf("%w, the group did not complete after %d attempts: %w", ErrTimeout, attempts,
+			lastErr.Err),
+		Caller: lastErr.Caller,
+	}
 }
 
-func (t *TitleMatch) test(testName string, fn func() error) *Sequence {
-	t.s.last = func() *Sequence {
-		if t.s.err != nil {
-			return t.s
-		}
-		title, err := t.s.driver.Title()
-		if err != nil {
-			t.s.err = &Error{
+// Consistently will retry the previous test every EventualPoll for the passed in duration, and fails as soon as the
+// test stops passing
+func (s *Sequence) Consistently(d time.Duration) *Sequence {
+	if s.err != nil {
+		return s
+	}
+	if s.stopped("Consistently", "", 0) {
+		return s
+	}
+
+	start := time.Now()
+	for i := 1; time.Since(start) < d; i++ {
+		if err := s.consistentlyWait("Consistently"); err != nil {
+			err.Caller = s.caller(0)
+			s.err = err
+			return s
+		}
+		s = s.last()
+		if s.err != nil {
+			s.err = consistentlyErr(s.err, time.Since(start), i)
+			s.err.Caller = s.caller(0)
+			return s
+		}
+	}
+	return s
+}
+
+// Consistently will re-select the elements and retry the previous test every EventualPoll for the passed in
+// duration, and fails as soon as the test stops passing
+func (e *Elements) Consistently(d time.Duration) *Elements {
+	if e.seq.failed() || e.last == nil {
+		return e
+	}
+	if e.seq.stopped("Consistently", e.selector, 0) {
+		return e
+	}
+
+	start := time.Now()
+	for i := 1; time.Since(start) < d; i++ {
+		if err := e.seq.consistentlyWait("Consistently '" + e.selector + "'"); err != nil {
+			err.Caller = e.seq.caller(0)
+			e.seq.err = err
+			return e
+		}
+		if e.selectFunc != nil && e.selector != "" {
+			var err error
+			e.elems, err = e.selectFunc(e.selector)
+			if err != nil {
+				e.seq.err = consistentlyErr(&Error{
+					Stage:    "Elements",
+					Selector: e.selector,
+					Err:      err,
+				}, time.Since(start), i)
+				e.seq.err.Caller = e.seq.caller(0)
+				return e
+			}
+		}
+		e = e.last()
+		if e.seq.err != nil {
+			e.seq.err = consistentlyErr(e.seq.err, time.Since(start), i)
+			e.seq.err.Caller = e.seq.caller(0)
+			return e
+		}
+	}
+	return e
+}
+
+func consistentlyErr(err *Error, held time.Duration, iteration int) *Error {
+	return &Error{
+		Stage:       "Consistently " + err.Stage,
+		Selector:    err.Selector,
+		Element:     err.Element,
+		ElementHTML: err.ElementHTML,
+		Err: fmt.Errorf("The test stopped passing after holding for %s on iteration %d: %s",
+			held.Round(time.Millisecond), iteration, err.Err),
+	}
+}
+
+// Test runs an arbitrary test against the entire page
+func (s *Sequence) Test(testName string, fn func(d selenium.WebDriver) error) *Sequence {
+	if s.failed() {
+		return s
+	}
+	s = s.test(testName, fn)
+	if s.err != nil {
+		s.err.Caller = s.caller(0)
+	}
+	return s
+}
+
+func (s *Sequence) test(testName string, fn func(d selenium.WebDriver) error) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped(testName, "", 2) {
+			return s
+		}
+
+		err := fn(s.driver)
+
+		if err != nil {
+			s.err = &Error{
+				Stage:  testName,
+				Err:    err,
+				Caller: s.caller(2),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// TitleMatch is for testing the value of the title
+type TitleMatch struct {
+	title string
+	s     *Sequence
+}
+
+func (t *TitleMatch) test(testName string, fn func() error) *Sequence {
+	t.s.last = func() *Sequence {
+		if t.s.failed() {
+			return t.s
+		}
+		if t.s.stopped("Title "+testName, "", 2) {
+			return t.s
+		}
+		title, err := t.s.driver.Title()
+		if err != nil {
+			t.s.err = &Error{
 				Stage:  "Title " + testName,
 				Err:    err,
-				Caller: caller(2),
+				Caller: t.s.caller(2),
 			}
 			return t.s
 		}
@@ -269,7 +1138,7 @@ func (t *TitleMatch) test(testName string, fn func() error) *Sequence {
 			t.s.err = &Error{
 				Stage:  "Title " + testName,
 				Err:    err,
-				Caller: caller(2),
+				Caller: t.s.caller(2),
 			}
 		}
 		return t.s
@@ -297,6 +1166,59 @@ func (t *TitleMatch) Contains(match string) *Sequence {
 	})
 }
 
+// NotEquals tests if the title does not match the passed in value exactly
+func (t *TitleMatch) NotEquals(match string) *Sequence {
+	return t.test("Not Equals", func() error {
+		if t.title == match {
+			return fmt.Errorf("The page's title equals '%s'", match)
+		}
+		return nil
+	})
+}
+
+// NotContains tests if the title does not contain the passed in value
+func (t *TitleMatch) NotContains(match string) *Sequence {
+	return t.test("Not Contains", func() error {
+		if strings.Contains(t.title, match) {
+			return fmt.Errorf("The pages's title contains '%s'. Got '%s'", match, t.title)
+		}
+		return nil
+	})
+}
+
+// EqualsFold tests if the title matches the passed in value under Unicode case folding
+func (t *TitleMatch) EqualsFold(match string) *Sequence {
+	return t.test("Equals Fold", func() error {
+		if !strings.EqualFold(t.title, match) {
+			return fmt.Errorf("The page's title does not equal '%s' (case insensitive). Got '%s'", match, t.title)
+		}
+		return nil
+	})
+}
+
+// ContainsFold tests if the title contains the passed in value under Unicode case folding
+func (t *TitleMatch) ContainsFold(match string) *Sequence {
+	return t.test("Contains Fold", func() error {
+		if !containsFold(t.title, match) {
+			return fmt.Errorf("The pages's title does not contain '%s' (case insensitive). Got '%s'", match,
+				t.title)
+		}
+		return nil
+	})
+}
+
+// OneOf tests if the title matches any of the passed in values exactly
+func (t *TitleMatch) OneOf(matches ...string) *Sequence {
+	return t.test("One Of", func() error {
+		for i := range matches {
+			if t.title == matches[i] {
+				return nil
+			}
+		}
+		return fmt.Errorf("The page's title does not equal any of %q. Got '%s'", matches, t.title)
+	})
+}
+
 // StartsWith tests if the title starts with the passed in value
 func (t *TitleMatch) StartsWith(match string) *Sequence {
 	return t.test("Starts With", func() error {
@@ -338,15 +1260,114 @@ func (s *Sequence) Title() *TitleMatch {
 // Get navigates to the passed in URI
 func (s *Sequence) Get(uri string) *Sequence {
 	s.last = func() *Sequence {
-		if s.err != nil {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Get '"+uri+"'", "", 1) {
 			return s
 		}
-		err := s.driver.Get(uri)
+		err := s.navigated(s.driver.Get(uri))
+		if err == nil && s.readyWait {
+			err = s.waitForReady()
+		}
 		if err != nil {
 			s.err = &Error{
 				Stage:  "Get",
 				Err:    err,
-				Caller: caller(1),
+				Caller: s.caller(1),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// GetWithRetry navigates to the passed in URI, and retries up to the number of attempts, waiting delay in between,
+// if navigating fails, such as when a server is restarting.  Waiting between attempts stops early if the sequence's
+// context is cancelled or its deadline is reached
+func (s *Sequence) GetWithRetry(uri string, attempts int, delay time.Duration) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Get With Retry '"+uri+"'", "", 1) {
+			return s
+		}
+		if attempts < 1 {
+			s.err = &Error{
+				Stage:  "Get With Retry",
+				Err:    fmt.Errorf("GetWithRetry needs at least 1 attempt, got %d", attempts),
+				Caller: s.caller(1),
+			}
+			return s
+		}
+		var errs Errors
+		tried := 0
+		for tried < attempts {
+			if tried > 0 {
+				if err := s.retryDelay(delay); err != nil {
+					errs = append(errs, err)
+					break
+				}
+			}
+			tried++
+			err := s.navigated(s.driver.Get(uri))
+			if err == nil && s.readyWait {
+				err = s.waitForReady()
+			}
+			if err == nil {
+				return s
+			}
+			errs = append(errs, fmt.Errorf("Attempt %d: %s", tried, err))
+		}
+		s.err = &Error{
+			Stage:  "Get With Retry",
+			Err:    fmt.Errorf("Navigating to %s failed after %d attempts: %s", uri, tried, errs),
+			Caller: s.caller(1),
+		}
+		return s
+	}
+	return s.last()
+}
+
+// retryDelay waits for the delay between retries, returning an error instead if the sequence's context is cancelled
+// or its deadline is reached before the delay is up
+func (s *Sequence) retryDelay(delay time.Duration) error {
+	wait := s.clampTimeout(delay)
+	if wait > 0 {
+		select {
+		case <-s.Context().Done():
+		case <-time.After(wait):
+		}
+	}
+	if err := s.waitCancelled(); err != nil {
+		return err
+	}
+	if wait < delay {
+		return errors.New("The sequence's deadline was reached before the next attempt")
+	}
+	return nil
+}
+
+// GetAndWait navigates to the passed in URI and waits for the page to be ready, regardless of whether WaitForReady
+// is enabled
+func (s *Sequence) GetAndWait(uri string) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Get And Wait '"+uri+"'", "", 1) {
+			return s
+		}
+		err := s.navigated(s.driver.Get(uri))
+		if err == nil {
+			err = s.waitForReady()
+		}
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Get And Wait",
+				Err:    err,
+				Caller: s.caller(1),
 			}
 		}
 		return s
@@ -357,12 +1378,16 @@ func (s *Sequence) Get(uri string) *Sequence {
 // URLMatch is for testing the value of the page's URL
 type URLMatch struct {
 	url *url.URL
+	raw string // the url as returned by the driver, before parsing
 	s   *Sequence
 }
 
 func (u *URLMatch) test(testName string, fn func() error) *Sequence {
 	u.s.last = func() *Sequence {
-		if u.s.err != nil {
+		if u.s.failed() {
+			return u.s
+		}
+		if u.s.stopped("URL "+testName, "", 2) {
 			return u.s
 		}
 		uri, err := u.s.driver.CurrentURL()
@@ -370,17 +1395,18 @@ func (u *URLMatch) test(testName string, fn func() error) *Sequence {
 			u.s.err = &Error{
 				Stage:  "URL " + testName,
 				Err:    err,
-				Caller: caller(2),
+				Caller: u.s.caller(2),
 			}
 			return u.s
 		}
 
+		u.raw = uri
 		u.url, err = url.Parse(uri)
 		if err != nil {
 			u.s.err = &Error{
 				Stage:  "URL " + testName,
 				Err:    err,
-				Caller: caller(2),
+				Caller: u.s.caller(2),
 			}
 			return u.s
 		}
@@ -389,7 +1415,7 @@ func (u *URLMatch) test(testName string, fn func() error) *Sequence {
 			u.s.err = &Error{
 				Stage:  "URL " + testName,
 				Err:    err,
-				Caller: caller(2),
+				Caller: u.s.caller(2),
 			}
 		}
 		return u.s
@@ -431,6 +1457,26 @@ func (u *URLMatch) QueryValue(key, value string) *Sequence {
 	})
 }
 
+// QueryKeyPresent tests if the page's url contains the query key, regardless of its value
+func (u *URLMatch) QueryKeyPresent(key string) *Sequence {
+	return u.test("Query Key Present", func() error {
+		if _, ok := u.url.Query()[key]; !ok {
+			return fmt.Errorf("URL does not contain the query key '%s'. URL: %s", key, u.url)
+		}
+		return nil
+	})
+}
+
+// QueryKeyAbsent tests if the page's url does not contain the query key
+func (u *URLMatch) QueryKeyAbsent(key string) *Sequence {
+	return u.test("Query Key Absent", func() error {
+		if v, ok := u.url.Query()[key]; ok {
+			return fmt.Errorf("URL contains the query key '%s' with the values %s. URL: %s", key, v, u.url)
+		}
+		return nil
+	})
+}
+
 // Fragment tests if the page's url fragment (#) matches the passed in value
 func (u *URLMatch) Fragment(match string) *Sequence {
 	return u.test("Fragment Matches", func() error {
@@ -441,6 +1487,27 @@ func (u *URLMatch) Fragment(match string) *Sequence {
 	})
 }
 
+// Regexp tests if the page's full url, exactly as the browser reports it, matches the regular expression
+func (u *URLMatch) Regexp(exp *regexp.Regexp) *Sequence {
+	return u.test("Matches RegExp", func() error {
+		if !exp.MatchString(u.raw) {
+			return fmt.Errorf("URL does not match the regular expression '%s'. URL: '%s'", exp, u.raw)
+		}
+		return nil
+	})
+}
+
+// PathRegexp tests if the page's url path matches the regular expression
+func (u *URLMatch) PathRegexp(exp *regexp.Regexp) *Sequence {
+	return u.test("Path Matches RegExp", func() error {
+		if !exp.MatchString(u.url.Path) {
+			return fmt.Errorf("URL's path does not match the regular expression '%s'. Path: '%s', URL: '%s'",
+				exp, u.url.Path, u.url)
+		}
+		return nil
+	})
+}
+
 // URL tests against the current page URL
 func (s *Sequence) URL() *URLMatch {
 	return &URLMatch{
@@ -451,16 +1518,22 @@ func (s *Sequence) URL() *URLMatch {
 // Forward moves forward in the browser's history
 func (s *Sequence) Forward() *Sequence {
 	s.last = func() *Sequence {
-		if s.err != nil {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Forward", "", 1) {
 			return s
 		}
 
-		err := s.driver.Forward()
+		err := s.navigated(s.driver.Forward())
+		if err == nil && s.readyWait {
+			err = s.waitForReady()
+		}
 		if err != nil {
 			s.err = &Error{
 				Stage:  "Forward",
 				Err:    err,
-				Caller: caller(1),
+				Caller: s.caller(1),
 			}
 		}
 		return s
@@ -471,16 +1544,22 @@ func (s *Sequence) Forward() *Sequence {
 // Back moves back in the browser's history
 func (s *Sequence) Back() *Sequence {
 	s.last = func() *Sequence {
-		if s.err != nil {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Back", "", 1) {
 			return s
 		}
 
-		err := s.driver.Back()
+		err := s.navigated(s.driver.Back())
+		if err == nil && s.readyWait {
+			err = s.waitForReady()
+		}
 		if err != nil {
 			s.err = &Error{
 				Stage:  "Back",
 				Err:    err,
-				Caller: caller(1),
+				Caller: s.caller(1),
 			}
 		}
 		return s
@@ -491,16 +1570,48 @@ func (s *Sequence) Back() *Sequence {
 // Refresh refreshes the page
 func (s *Sequence) Refresh() *Sequence {
 	s.last = func() *Sequence {
-		if s.err != nil {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Refresh", "", 1) {
 			return s
 		}
 
-		err := s.driver.Refresh()
+		err := s.navigated(s.driver.Refresh())
+		if err == nil && s.readyWait {
+			err = s.waitForReady()
+		}
 		if err != nil {
 			s.err = &Error{
 				Stage:  "Refresh",
 				Err:    err,
-				Caller: caller(1),
+				Caller: s.caller(1),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
+
+// PressKey sends a single key to the page's currently active element, i.e. selenium.TabKey
+func (s *Sequence) PressKey(key string) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Press Key", "", 1) {
+			return s
+		}
+
+		active, err := s.driver.ActiveElement()
+		if err == nil {
+			err = active.SendKeys(key)
+		}
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Press Key",
+				Err:    err,
+				Caller: s.caller(1),
 			}
 		}
 		return s
@@ -510,8 +1621,12 @@ func (s *Sequence) Refresh() *Sequence {
 
 // Find returns a selection of one or more elements to apply a set of actions against
 // If .Any or.All are not specified, then it is assumed that the selection will contain a single element
-// and the tests will fail if more than one element is found
+// and the tests will fail if more than one element is found.  Inside Within, only elements inside the container are
+// selected
 func (s *Sequence) Find(selector string) *Elements {
+	if len(s.scopes) > 0 {
+		return s.findWithin(selector)
+	}
 	e := &Elements{
 		seq:      s,
 		selector: selector,
@@ -520,19 +1635,23 @@ func (s *Sequence) Find(selector string) *Elements {
 		},
 	}
 
-	if s.err != nil {
+	if s.failed() {
 		return e
 	}
 
 	e.last = func() *Elements {
+		if s.stopped("Find", selector, 1) {
+			return e
+		}
 		var err error
 		e.elems, err = e.selectFunc(selector)
 
 		if err != nil {
 			s.err = &Error{
-				Stage:  "Elements",
-				Err:    err,
-				Caller: caller(1),
+				Stage:    "Elements",
+				Selector: selector,
+				Err:      err,
+				Caller:   s.caller(1),
 			}
 			return e
 		}
@@ -543,22 +1662,28 @@ func (s *Sequence) Find(selector string) *Elements {
 
 // Wait will wait for the given duration before continuing in the sequence
 func (s *Sequence) Wait(duration time.Duration) *Sequence {
-	if s.err != nil {
+	if s.failed() {
 		return s
 	}
 	time.Sleep(duration)
 	return s
 }
 
-// Debug will print the current page's title and source
+// Debug will print the current page's title and source to the sequence's output.  Options can limit how much of
+// the source is printed, see DebugMaxSource, DebugSelector and DebugNoSource
 // For use with debugging issues mostly
-func (s *Sequence) Debug() *Sequence {
-	src, err := s.driver.PageSource()
+func (s *Sequence) Debug(opts ...DebugOption) *Sequence {
+	o := &debugOptions{}
+	for i := range opts {
+		opts[i](o)
+	}
+
+	src, err := s.debugSource(o)
 	if err != nil {
 		s.err = &Error{
 			Stage:  "Debug Source",
 			Err:    err,
-			Caller: caller(0),
+			Caller: s.caller(0),
 		}
 		return s
 	}
@@ -568,7 +1693,7 @@ func (s *Sequence) Debug() *Sequence {
 		s.err = &Error{
 			Stage:  "Debug Title",
 			Err:    err,
-			Caller: caller(0),
+			Caller: s.caller(0),
 		}
 		return s
 	}
@@ -578,54 +1703,104 @@ func (s *Sequence) Debug() *Sequence {
 		s.err = &Error{
 			Stage:  "Debug URL",
 			Err:    err,
-			Caller: caller(0),
+			Caller: s.caller(0),
 		}
 		return s
 	}
 
-	// logs, err := s.driver.Log(log.Browser)
-	// if err != nil {
-	// 	s.err = &Error{
-	// 		Stage:  "Debug Log",
-	// 		Err:    err,
-	// 		Caller: caller(0),
-	// 	}
-	// 	return s
-	// }
-	// log := ""
-	// for i := range logs {
-	// 	log += fmt.Sprintf("%s - (%s): %s\n", logs[i].Level, logs[i].Timestamp.Format(time.Stamp), logs[i].Message)
-	// }
-
-	fmt.Println("-----------------------------------------------")
-	fmt.Printf("%s - (%s)\n", title, uri)
-	fmt.Println("-----------------------------------------------")
-	fmt.Println(src)
-	fmt.Println("-----------------------------------------------")
-	// fmt.Println("LOG")
-	// fmt.Println(log)
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "-----------------------------------------------")
+	fmt.Fprintf(&out, "%s - (%s)\n", title, uri)
+	fmt.Fprintln(&out, "-----------------------------------------------")
+	if !o.noSource {
+		fmt.Fprintln(&out, redact(src, s.redacted))
+		fmt.Fprintln(&out, "-----------------------------------------------")
+	}
+	fmt.Fprintln(&out, "LOG")
+	fmt.Fprintln(&out, redact(s.debugLog(), s.redacted))
+	s.out().Write(out.Bytes())
+	return s
+}
+
+// DebugLog will print the browser's log to the sequence's output.
+// For use with debugging issues mostly
+func (s *Sequence) DebugLog() *Sequence {
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "-----------------------------------------------")
+	fmt.Fprintln(&out, "LOG")
+	fmt.Fprintln(&out, redact(s.debugLog(), s.redacted))
+	fmt.Fprintln(&out, "-----------------------------------------------")
+	s.out().Write(out.Bytes())
 	return s
 }
 
-// Screenshot takes a screenshot
+// debugLog returns the browser's log formatted for printing.  Not all drivers support reading the browser's log
+// so errors are included in the output rather than failing the sequence
+func (s *Sequence) debugLog() string {
+	logs, err := s.browserLog()
+	if err != nil {
+		return fmt.Sprintf("(browser log not available: %s)", err)
+	}
+	str := ""
+	for i := range logs {
+		str += fmt.Sprintf("%s - (%s): %s\n", logs[i].Level, logs[i].Timestamp.Format(time.Stamp), logs[i].Message)
+	}
+	return str
+}
+
+// Screenshot takes a screenshot.  The file name can contain these tokens, so screenshots don't overwrite each other:
+//
+//	{time}  the current time, like 20060102-150405.000
+//	{test}  the test's name, once it's known from Ok, otherwise "sequence"
+//	{step}  the name of the current step set by Step, otherwise "step"
+//	{seq}   a counter which goes up by one for each file name using it in the sequence
+//
+// such as "shots/{test}-{step}-{time}.png"
 func (s *Sequence) Screenshot(filename string) *Sequence {
+	return s.screenshot("Screenshot Writing File", func(buff []byte) error {
+		name, err := s.filename(filename)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(name, buff, 0622)
+	})
+}
+
+// ScreenshotTo takes a screenshot and writes the PNG to w
+func (s *Sequence) ScreenshotTo(w io.Writer) *Sequence {
+	return s.screenshot("Screenshot Write", func(buff []byte) error {
+		_, err := w.Write(buff)
+		return err
+	})
+}
+
+// ScreenshotBytes takes a screenshot and stores the PNG in dest
+func (s *Sequence) ScreenshotBytes(dest *[]byte) *Sequence {
+	return s.screenshot("Screenshot Write", func(buff []byte) error {
+		*dest = buff
+		return nil
+	})
+}
+
+// screenshot takes a screenshot and passes it to fn, failing with the stage if fn returns an error
+func (s *Sequence) screenshot(stage string, fn func(buff []byte) error) *Sequence {
 	buff, err := s.driver.Screenshot()
 	if err != nil {
 		s.err = &Error{
 			Stage:  "Screenshot",
 			Err:    err,
-			Caller: caller(1),
+			Caller: s.caller(2),
 		}
 		return s
 	}
 
-	err = ioutil.WriteFile(filename, buff, 0622)
+	err = fn(buff)
 	if err != nil {
 		s.err = &Error{
-			Stage: "Screenshot Writing File",
-			Err:   err,
+			Stage:  stage,
+			Err:    err,
+			Caller: s.caller(2),
 		}
-		return s
 	}
 	return s
 }
@@ -637,12 +1812,13 @@ func (e *Elements) End() error {
 
 // Ok is a shortcut for Sequence.Ok
 func (e *Elements) Ok(tb testing.TB) {
+	tb.Helper()
 	e.seq.Ok(tb)
 }
 
 // Wait sleeps for the given duration
 func (e *Elements) Wait(duration time.Duration) *Elements {
-	if e.seq.err != nil {
+	if e.seq.failed() {
 		return e
 	}
 	time.Sleep(duration)
@@ -652,6 +1828,7 @@ func (e *Elements) Wait(duration time.Duration) *Elements {
 // Any means the following tests will pass if they pass for ANY of the selected elements
 func (e *Elements) Any() *Elements {
 	e.all = false
+	e.none = false
 	e.any = true
 	return e
 }
@@ -659,98 +1836,398 @@ func (e *Elements) Any() *Elements {
 // All means the following tests will pass if they pass only if pass for ALL of the selected elements
 func (e *Elements) All() *Elements {
 	e.any = false
+	e.none = false
 	e.all = true
 	return e
 }
 
+// None means the following tests will pass only if they fail for ALL of the selected elements
+func (e *Elements) None() *Elements {
+	e.any = false
+	e.all = false
+	e.none = true
+	return e
+}
+
 // Count verifies that the number of elements in the selection matches the argument
 func (e *Elements) Count(count int) *Elements {
-	e.last = func() *Elements {
-		if e.seq.err != nil {
-			return e
-		}
+	return e.count("Count", func(n int) bool { return n == count }, fmt.Sprintf("wanted %d", count))
+}
 
-		if count != len(e.elems) {
+// CountAtLeast verifies that the selection contains at least the given number of elements
+func (e *Elements) CountAtLeast(count int) *Elements {
+	return e.count("Count At Least", func(n int) bool { return n >= count }, fmt.Sprintf("wanted at least %d", count))
+}
+
+// CountAtMost verifies that the selection contains at most the given number of elements
+func (e *Elements) CountAtMost(count int) *Elements {
+	return e.count("Count At Most", func(n int) bool { return n <= count }, fmt.Sprintf("wanted at most %d", count))
+}
+
+// CountGreaterThan verifies that the selection contains more than the given number of elements
+func (e *Elements) CountGreaterThan(count int) *Elements {
+	return e.count("Count Greater Than", func(n int) bool { return n > count },
+		fmt.Sprintf("wanted more than %d", count))
+}
+
+// CountBetween verifies that the number of elements in the selection is between min and max inclusive
+func (e *Elements) CountBetween(min, max int) *Elements {
+	return e.count("Count Between", func(n int) bool { return n >= min && n <= max },
+		fmt.Sprintf("wanted between %d and %d", min, max))
+}
+
+func (e *Elements) count(stage string, ok func(n int) bool, wanted string) *Elements {
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 2) {
+			return e
+		}
+
+		if !ok(len(e.elems)) {
 			e.seq.err = &Error{
-				Stage: "Count",
-				Err: fmt.Errorf("Invalid count for selector %s wanted %d got %d", e.selector, count,
+				Stage:    stage,
+				Selector: e.selector,
+				Err: fmt.Errorf("Invalid count for selector %s %s got %d%s", e.selector, wanted, len(e.elems),
+					e.filterSummary()),
+				Caller: e.seq.caller(2),
+			}
+
+			return e
+		}
+		return e
+	}
+	return e.last()
+}
+
+// Present verifies that at least one element matches the selector
+func (e *Elements) Present() *Elements {
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped("Present", e.selector, 1) {
+			return e
+		}
+
+		if len(e.elems) == 0 {
+			e.seq.err = &Error{
+				Stage:    "Present",
+				Selector: e.selector,
+				Err:      e.noElements(),
+				Caller:   e.seq.caller(1),
+			}
+		}
+		return e
+	}
+	return e.last()
+}
+
+// NotPresent verifies that no elements match the selector.  Combined with Eventually it can be used to wait for
+// an element to be removed from the page
+func (e *Elements) NotPresent() *Elements {
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped("Not Present", e.selector, 1) {
+			return e
+		}
+
+		if len(e.elems) != 0 {
+			e.seq.err = &Error{
+				Stage:    "Not Present",
+				Selector: e.selector,
+				Err: fmt.Errorf("Selector '%s' should match no elements but matched %d", e.selector,
 					len(e.elems)),
-				Caller: caller(1),
+				Caller: e.seq.caller(1),
 			}
+		}
+		return e
+	}
+	return e.last()
+}
 
+// Gone verifies that the selected elements have gone away, meaning no elements match the selector, or every element
+// that does match is either no longer attached to the page or isn't displayed.  Combine with Eventually to wait
+// for spinners and modals to disappear
+func (e *Elements) Gone() *Elements {
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped("Gone", e.selector, 1) {
 			return e
 		}
+
+		visible := 0
+		for i := range e.elems {
+			displayed, err := e.elems[i].IsDisplayed()
+			if err != nil {
+				if isStaleElement(err) {
+					continue
+				}
+				e.seq.err = &Error{
+					Stage:       "Gone",
+					Selector:    e.selector,
+					Element:     e.elems[i],
+					ElementHTML: e.seq.elementHTML(e.elems[i]),
+					Err:         err,
+					Caller:      e.seq.caller(1),
+				}
+				return e
+			}
+			if displayed {
+				visible++
+			}
+		}
+		if visible > 0 {
+			e.seq.err = &Error{
+				Stage:    "Gone",
+				Selector: e.selector,
+				Err: fmt.Errorf("Selector '%s' still matches %d elements, %d of which are visible", e.selector,
+					len(e.elems), visible),
+				Caller: e.seq.caller(1),
+			}
+		}
 		return e
 	}
 	return e.last()
 }
 
+// maxStaleRetries is how many times a test is retried against freshly selected elements when RetryStale is enabled
+const maxStaleRetries = 3
+
+// RetryStale sets whether element tests which fail because an element has gone stale are retried against freshly
+// selected elements, which is common in pages which re-render often
+func (s *Sequence) RetryStale(enabled bool) *Sequence {
+	s.retryStale = enabled
+	return s
+}
+
+// isStaleElement returns whether the error is WebDriver's stale element reference error
+func isStaleElement(err error) bool {
+	if sErr, ok := err.(*selenium.Error); ok {
+		return sErr.Err == "stale element reference"
+	}
+	return strings.Contains(err.Error(), "stale element reference")
+}
+
+// First narrows the selection to the first selected element
+func (e *Elements) First() *Elements {
+	return e.nth("First", 0)
+}
+
+// Last narrows the selection to the last selected element
+func (e *Elements) Last() *Elements {
+	return e.nth("Last", -1)
+}
+
+// Nth narrows the selection to the element at index i.  Negative indexes count back from the end of the
+// selection, so Nth(-1) is the last element
+func (e *Elements) Nth(i int) *Elements {
+	return e.nth("Nth", i)
+}
+
+func (e *Elements) nth(stage string, i int) *Elements {
+	if e.selectFunc != nil {
+		selectFunc := e.selectFunc
+		e.selectFunc = func(selector string) ([]selenium.WebElement, error) {
+			elems, err := selectFunc(selector)
+			if err != nil {
+				return nil, err
+			}
+			return nthElement(elems, i, selector)
+		}
+	}
+
+	if e.seq.failed() {
+		return e
+	}
+
+	var err error
+	e.elems, err = nthElement(e.elems, i, e.selector)
+	if err != nil {
+		e.seq.err = &Error{
+			Stage:    stage,
+			Selector: e.selector,
+			Err:      err,
+			Caller:   e.seq.caller(1),
+		}
+	}
+	return e
+}
+
+func nthElement(elems []selenium.WebElement, i int, selector string) ([]selenium.WebElement, error) {
+	index := i
+	if index < 0 {
+		index += len(elems)
+	}
+	if index < 0 || index >= len(elems) {
+		return nil, fmt.Errorf("Index %d is out of range for selector '%s' which matched %d elements", i,
+			selector, len(elems))
+	}
+	return []selenium.WebElement{elems[index]}, nil
+}
+
+// Slice narrows the selection to the selected elements from index start up to, but not including, end
+func (e *Elements) Slice(start, end int) *Elements {
+	if e.selectFunc != nil {
+		selectFunc := e.selectFunc
+		e.selectFunc = func(selector string) ([]selenium.WebElement, error) {
+			elems, err := selectFunc(selector)
+			if err != nil {
+				return nil, err
+			}
+			return sliceElements(elems, start, end, selector)
+		}
+	}
+
+	if e.seq.failed() {
+		return e
+	}
+	if e.seq.stopped("Slice", e.selector, 0) {
+		return e
+	}
+
+	var err error
+	e.elems, err = sliceElements(e.elems, start, end, e.selector)
+	if err != nil {
+		e.seq.err = &Error{
+			Stage:    "Slice",
+			Selector: e.selector,
+			Err:      err,
+			Caller:   e.seq.caller(0),
+		}
+	}
+	return e
+}
+
+func sliceElements(elems []selenium.WebElement, start, end int, selector string) ([]selenium.WebElement, error) {
+	if start < 0 || end > len(elems) || start > end {
+		return nil, fmt.Errorf("Range [%d:%d] is out of bounds for selector '%s' which matched %d elements",
+			start, end, selector, len(elems))
+	}
+	return elems[start:end], nil
+}
+
 // And allows you chain additional sequences
 func (e *Elements) And() *Sequence {
 	return e.seq
 }
 
+// WebElements returns a copy of the currently selected elements, for passing to code outside of the sequence.  The
+// elements aren't re-selected, so they may go stale if the page changes, and changing the returned slice doesn't
+// affect the sequence
+func (e *Elements) WebElements() []selenium.WebElement {
+	return append([]selenium.WebElement{}, e.elems...)
+}
+
+// Selector returns the CSS selector the elements were selected with
+func (e *Elements) Selector() string {
+	return e.selector
+}
+
+// Err returns the sequence's current error, if any, without ending the sequence
+func (e *Elements) Err() error {
+	if e.seq.err == nil {
+		return nil
+	}
+	return e.seq.err
+}
+
 // Find finds a new element
 func (e *Elements) Find(selector string) *Elements {
 	return e.seq.Find(selector)
 }
 
-// FindChildren returns a new Elements object for all the elements that match the selector
+// FindChildren returns a new Elements object for all the elements that match the selector under any of the
+// elements.  When the children are re-selected, such as by Eventually, the parent elements are re-selected first, so
+// parents which have gone stale or been re-rendered are found again.  The children start without a quantifier (Any,
+// All or None) regardless of the parent's
 func (e *Elements) FindChildren(selector string) *Elements {
 	newE := &Elements{
 		seq:      e.seq,
 		selector: selector,
-		selectFunc: func(selector string) ([]selenium.WebElement, error) {
-			var found []selenium.WebElement
-			success := false
-			var lastErr error
-			var lastElement selenium.WebElement
-
-			for i := range e.elems {
-				elements, err := e.elems[i].FindElements(selenium.ByCSSSelector, selector)
-				if err != nil {
-					lastElement = e.elems[i]
-					lastErr = err
-					continue
-				}
-				found = append(found, elements...)
-				success = true
-			}
-			if !success {
-				// all find elements calls failed
-				return nil, &Error{
-					Stage:   "Find Children",
-					Element: lastElement,
-					Err:     lastErr,
-					Caller:  caller(1),
-				}
+	}
+	// re-selected parents are kept here rather than in e, so retrying the children doesn't change the parent's
+	// selection
+	parents := e.elems
+	newE.selectFunc = func(selector string) ([]selenium.WebElement, error) {
+		if e.selectFunc != nil && e.selector != "" {
+			elems, err := e.selectFunc(e.selector)
+			if err != nil {
+				return nil, err
 			}
-			return found, nil
-		},
+			parents = elems
+		}
+		return findChildren(parents, selector)
 	}
-	if e.seq.err != nil {
-		return e
+	if e.seq.failed() {
+		return newE
 	}
 
-	var err error
+	newE.last = func() *Elements {
+		if e.seq.stopped("Find Children", selector, 1) {
+			return newE
+		}
+		var err error
+		newE.elems, err = findChildren(parents, selector)
+		if err != nil {
+			sErr := err.(*Error)
+			sErr.Selector = selector
+			sErr.ElementHTML = e.seq.elementHTML(sErr.Element)
+			sErr.Caller = e.seq.caller(1)
+			e.seq.err = sErr
+		}
+		return newE
+	}
+	return newE.last()
+}
 
-	newE.elems, err = newE.selectFunc(selector)
-	if err != nil {
-		newE.seq.err = err.(*Error)
+// findChildren finds all of the elements matching the selector under any of the parent elements
+func findChildren(parents []selenium.WebElement, selector string) ([]selenium.WebElement, error) {
+	if len(parents) == 0 {
+		return nil, nil
 	}
 
-	return newE
+	var found []selenium.WebElement
+	success := false
+	var lastErr error
+	var lastElement selenium.WebElement
+
+	for i := range parents {
+		elements, err := parents[i].FindElements(selenium.ByCSSSelector, selector)
+		if err != nil {
+			lastElement = parents[i]
+			lastErr = err
+			continue
+		}
+		found = append(found, elements...)
+		success = true
+	}
+	if !success {
+		// all find elements calls failed
+		return nil, &Error{
+			Stage:   "Find Children",
+			Element: lastElement,
+			Err:     lastErr,
+			Caller:  caller(2),
+		}
+	}
+	return found, nil
 }
 
 // Test tests an arbitrary function against all the elements in this sequence
 // if the function returns an error then the test fails
 func (e *Elements) Test(testName string, fn func(e selenium.WebElement) error) *Elements {
-	if e.seq.err != nil {
+	if e.seq.failed() {
 		return e
 	}
 	e = e.test(testName, fn)
 	if e.seq.err != nil {
-		e.seq.err.Caller = caller(0)
+		e.seq.err.Caller = e.seq.caller(0)
 	}
 	return e
 }
@@ -758,76 +2235,165 @@ func (e *Elements) Test(testName string, fn func(e selenium.WebElement) error) *
 func (e *Elements) test(testName string, fn func(e selenium.WebElement) error) *Elements {
 	stage := testName + " Test"
 	e.last = func() *Elements {
-		if e.seq.err != nil {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 2) {
 			return e
 		}
 
+		at := e.seq.caller(2)
+		err := e.check(stage, fn, at)
+		retries := 0
+		for err != nil && e.seq.retryStale && e.selectFunc != nil && e.selector != "" && isStaleElement(err.Err) &&
+			retries < maxStaleRetries {
+			retries++
+			elems, sErr := e.selectFunc(e.selector)
+			if sErr != nil {
+				err = &Error{
+					Stage:  "Elements",
+					Err:    sErr,
+					Caller: at,
+				}
+				break
+			}
+			e.elems = elems
+			err = e.check(stage, fn, at)
+		}
+		if err != nil && retries > 0 {
+			err.Err = fmt.Errorf("%w (%d stale element retries were attempted)", err.Err, retries)
+		}
+		if err != nil {
+			err.Selector = e.selector
+			err.ElementHTML = e.seq.elementHTML(err.Element)
+			e.seq.err = err
+		}
+		return e
+	}
+	return e.last()
+}
+
+// Each runs fn against every selected element in order, along with the element's index, stopping at the first
+// element which fails.  Unlike Test, it ignores the elements' quantifier
+func (e *Elements) Each(name string, fn func(i int, we selenium.WebElement) error) *Elements {
+	return e.each(name+" Each", fn)
+}
+
+func (e *Elements) each(stage string, fn func(i int, we selenium.WebElement) error) *Elements {
+	if e.seq.failed() {
+		return e
+	}
+	e.last = func() *Elements {
+		if e.seq.failed() {
+			return e
+		}
+		if e.seq.stopped(stage, e.selector, 2) {
+			return e
+		}
 		if len(e.elems) == 0 {
 			e.seq.err = &Error{
-				Stage:  stage,
-				Err:    fmt.Errorf("No elements exist for the selector '%s'", e.selector),
-				Caller: caller(2),
+				Stage:    stage,
+				Selector: e.selector,
+				Err:      e.noElements(),
+				Caller:   e.seq.caller(2),
 			}
 			return e
 		}
-		if len(e.elems) == 1 {
-			err := fn(e.elems[0])
+		for i := range e.elems {
+			err := fn(i, e.elems[i])
 			if err != nil {
 				e.seq.err = &Error{
-					Stage:   stage,
-					Element: e.elems[0],
-					Err:     err,
-					Caller:  caller(2),
+					Stage:       stage,
+					Element:     e.elems[i],
+					ElementHTML: e.seq.elementHTML(e.elems[i]),
+					Selector:    e.selector,
+					Err:         fmt.Errorf("Element %d of %d failed: %w", i+1, len(e.elems), err),
+					Caller:      e.seq.caller(2),
 				}
+				return e
 			}
-			return e
 		}
+		return e
+	}
+	return e.last()
+}
 
-		if !e.any && !e.all {
-			e.seq.err = &Error{
-				Stage: stage,
-				Err: fmt.Errorf("Selector '%s' returned multiple elements but .Any() or .All() weren't specified",
-					e.selector),
-				Caller: caller(2),
-			}
-			return e
+// check runs the test function against the elements, according to the elements' quantifier
+func (e *Elements) check(stage string, fn func(e selenium.WebElement) error, at string) *Error {
+	if len(e.elems) == 0 {
+		return &Error{
+			Stage:  stage,
+			Err:    e.noElements(),
+			Caller: at,
 		}
+	}
 
-		var errs Errors
-
+	if e.none {
 		for i := range e.elems {
-			err := fn(e.elems[i])
-			if err != nil {
-				if e.all {
-					e.seq.err = &Error{
-						Stage:   stage,
-						Element: e.elems[i],
-						Err:     fmt.Errorf("Not All elements passed: %s", err),
-						Caller:  caller(2),
-					}
-					return e
-				}
-				errs = append(errs, &Error{
+			if fn(e.elems[i]) == nil {
+				return &Error{
 					Stage:   stage,
 					Element: e.elems[i],
-					Err:     err,
-					Caller:  caller(2),
-				})
-			} else if e.any {
-				return e
+					Err:     fmt.Errorf("Element %d of %d passed when None were expected to", i+1, len(e.elems)),
+					Caller:  at,
+				}
 			}
 		}
-		if len(errs) != 0 {
-			e.seq.err = &Error{
-				Stage:  stage,
-				Err:    fmt.Errorf("None of the elements passed: %s", errs),
-				Caller: caller(2),
+		return nil
+	}
+
+	if len(e.elems) == 1 {
+		err := fn(e.elems[0])
+		if err != nil {
+			return &Error{
+				Stage:   stage,
+				Element: e.elems[0],
+				Err:     err,
+				Caller:  at,
 			}
+		}
+		return nil
+	}
 
+	if !e.any && !e.all {
+		return &Error{
+			Stage:  stage,
+			Err:    fmt.Errorf("Selector '%s' %w", e.selector, ErrMultipleElementsWithoutQuantifier),
+			Caller: at,
 		}
-		return e
 	}
-	return e.last()
+
+	var errs Errors
+
+	for i := range e.elems {
+		err := fn(e.elems[i])
+		if err != nil {
+			if e.all {
+				return &Error{
+					Stage:   stage,
+					Element: e.elems[i],
+					Err:     fmt.Errorf("Not All elements passed: %s", err),
+					Caller:  at,
+				}
+			}
+			errs = append(errs, &Error{
+				Stage:   stage,
+				Element: e.elems[i],
+				Err:     err,
+				Caller:  at,
+			})
+		} else if e.any {
+			return nil
+		}
+	}
+	if len(errs) != 0 {
+		return &Error{
+			Stage:  stage,
+			Err:    fmt.Errorf("None of the elements passed: %s", errs),
+			Caller: at,
+		}
+	}
+	return nil
 }
 
 // Visible tests if the elements are visible
@@ -865,132 +2431,408 @@ func (e *Elements) Enabled() *Elements {
 		if err != nil {
 			return err
 		}
-		if !ok {
-			return errors.New("Element was not enabled")
+		if !ok {
+			return errors.New("Element was not enabled")
+		}
+		return nil
+	})
+}
+
+// Disabled tests if the elements are hidden
+func (e *Elements) Disabled() *Elements {
+	return e.test("Disabled", func(we selenium.WebElement) error {
+		ok, err := we.IsEnabled()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errors.New("Element was not disabled")
+		}
+		return nil
+	})
+}
+
+// Selected tests if the elements are selected
+func (e *Elements) Selected() *Elements {
+	return e.test("Selected", func(we selenium.WebElement) error {
+		ok, err := we.IsSelected()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("Element was not selected")
+		}
+		return nil
+	})
+}
+
+// Unselected tests if the elements aren't selected
+func (e *Elements) Unselected() *Elements {
+	return e.test("Selected", func(we selenium.WebElement) error {
+		ok, err := we.IsSelected()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errors.New("Element was selected")
+		}
+		return nil
+	})
+}
+
+// HasClass tests if the elements have all of the passed in classes.  Classes are compared as whole names, so
+// "active" doesn't match "inactive"
+func (e *Elements) HasClass(names ...string) *Elements {
+	return e.test("Has Class", func(we selenium.WebElement) error {
+		classes, err := we.GetAttribute("class")
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !hasClass(classes, name) {
+				return fmt.Errorf("Element does not have the class '%s'. Classes: '%s'", name, classes)
+			}
+		}
+		return nil
+	})
+}
+
+// NotHasClass tests if the elements have none of the passed in classes
+func (e *Elements) NotHasClass(names ...string) *Elements {
+	return e.test("Not Has Class", func(we selenium.WebElement) error {
+		classes, err := we.GetAttribute("class")
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if hasClass(classes, name) {
+				return fmt.Errorf("Element has the class '%s'. Classes: '%s'", name, classes)
+			}
+		}
+		return nil
+	})
+}
+
+const attributeScript = `
+return arguments[0].hasAttribute(arguments[1]) ? arguments[0].getAttribute(arguments[1]) : null;`
+
+// HasAttribute tests if the elements have the attribute, whatever its value.  Use it for boolean attributes such as
+// disabled or required, which selenium reports as an empty string whether they are present with no value or absent
+func (e *Elements) HasAttribute(name string) *Elements {
+	return e.test("Has Attribute", func(we selenium.WebElement) error {
+		_, ok, err := e.seq.attribute(we, name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("Element does not have the attribute '%s'", name)
+		}
+		return nil
+	})
+}
+
+// NoAttribute tests if the elements don't have the attribute
+func (e *Elements) NoAttribute(name string) *Elements {
+	return e.test("No Attribute", func(we selenium.WebElement) error {
+		val, ok, err := e.seq.attribute(we, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return fmt.Errorf("Element has the attribute '%s' with the value '%s'", name, val)
+		}
+		return nil
+	})
+}
+
+// attribute returns the value of the element's attribute, and whether the element has the attribute
+func (s *Sequence) attribute(we selenium.WebElement, name string) (string, bool, error) {
+	result, err := s.driver.ExecuteScript(attributeScript, []interface{}{we, name})
+	if err != nil {
+		return "", false, err
+	}
+	if result == nil {
+		return "", false, nil
+	}
+	val, ok := result.(string)
+	if !ok {
+		return "", false, fmt.Errorf("Element's attribute '%s' was a %T, not a string", name, result)
+	}
+	return val, true, nil
+}
+
+// hasClass returns whether the whitespace separated list of classes contains the class name
+func hasClass(classes, name string) bool {
+	for _, class := range strings.Fields(classes) {
+		if class == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StringMatch is for testing the value of strings in elements
+type StringMatch struct {
+	testName string
+	value    func(selenium.WebElement) (string, error)
+	html     bool
+	source   func() string
+	e        *Elements
+}
+
+// test runs the string test against the elements, noting where the last value was read from in failures if the
+// match has a source
+func (s *StringMatch) test(testName string, fn func(we selenium.WebElement) error) *Elements {
+	defer s.e.AddCallerSkip(1).AddCallerSkip(-1)
+	if s.source == nil {
+		return s.e.test(testName, fn)
+	}
+	return s.e.test(testName, func(we selenium.WebElement) error {
+		err := fn(we)
+		if err != nil {
+			return fmt.Errorf("%w (%s)", err, s.source())
+		}
+		return nil
+	})
+}
+
+// got returns the value for failure messages.  HTML values longer than the sequence's HTMLMatchLimit are truncated
+// to the part around where they first differ from match
+func (s *StringMatch) got(val, match string) string {
+	limit := s.e.seq.htmlMatchLimit
+	if !s.html || limit <= 0 || len(val) <= limit {
+		return val
+	}
+	index := 0
+	for index < len(val) && index < len(match) && val[index] == match[index] {
+		index++
+	}
+	start := index - limit/2
+	if start+limit > len(val) {
+		start = len(val) - limit
+	}
+	if start < 0 {
+		start = 0
+	}
+	for start > 0 && !utf8.RuneStart(val[start]) {
+		start--
+	}
+	end := start + limit
+	for end < len(val) && !utf8.RuneStart(val[end]) {
+		end++
+	}
+	result := val[start:end]
+	if start > 0 {
+		result = "..." + result
+	}
+	if end < len(val) {
+		result += "..."
+	}
+	return result
+}
+
+// Equals tests if the string value matches the passed in value exactly
+func (s *StringMatch) Equals(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Equals", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
+		if err != nil {
+			return err
+		}
+		if val != match {
+			return fmt.Errorf("The element's %s does not equal '%s'. Got '%s'", s.testName, match, s.got(val, match))
+		}
+		return nil
+	})
+}
+
+// Contains tests if the string value contains the passed in value
+func (s *StringMatch) Contains(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Contains", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(val, match) {
+			return fmt.Errorf("The Element's %s does not contain '%s'. Got '%s'", s.testName, match, s.got(val, match))
+		}
+		return nil
+	})
+}
+
+// EqualsFold tests if the string value matches the passed in value under Unicode case folding
+func (s *StringMatch) EqualsFold(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Equals Fold", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(val, match) {
+			return fmt.Errorf("The element's %s does not equal '%s' (case insensitive). Got '%s'", s.testName,
+				match, s.got(val, match))
+		}
+		return nil
+	})
+}
+
+// ContainsFold tests if the string value contains the passed in value under Unicode case folding
+func (s *StringMatch) ContainsFold(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Contains Fold", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
+		if err != nil {
+			return err
+		}
+		if !containsFold(val, match) {
+			return fmt.Errorf("The Element's %s does not contain '%s' (case insensitive). Got '%s'", s.testName,
+				match, s.got(val, match))
 		}
 		return nil
 	})
 }
 
-// Disabled tests if the elements are hidden
-func (e *Elements) Disabled() *Elements {
-	return e.test("Disabled", func(we selenium.WebElement) error {
-		ok, err := we.IsEnabled()
+// OneOf tests if the string value matches any of the passed in values exactly
+func (s *StringMatch) OneOf(matches ...string) *Elements {
+	return s.test(fmt.Sprintf("%s One Of", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if ok {
-			return errors.New("Element was not disabled")
+		for i := range matches {
+			if val == matches[i] {
+				return nil
+			}
 		}
-		return nil
+		return fmt.Errorf("The element's %s does not equal any of %q. Got '%s'", s.testName, matches,
+			s.got(val, ""))
 	})
 }
 
-// Selected tests if the elements are selected
-func (e *Elements) Selected() *Elements {
-	return e.test("Selected", func(we selenium.WebElement) error {
-		ok, err := we.IsSelected()
+// StartsWith tests if the string value starts with the passed in value
+func (s *StringMatch) StartsWith(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Starts With", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if !ok {
-			return errors.New("Element was not selected")
+		if !strings.HasPrefix(val, match) {
+			return fmt.Errorf("The Element's %s does not start with '%s'. Got '%s'", s.testName, match,
+				s.got(val, match))
 		}
 		return nil
 	})
 }
 
-// Unselected tests if the elements aren't selected
-func (e *Elements) Unselected() *Elements {
-	return e.test("Selected", func(we selenium.WebElement) error {
-		ok, err := we.IsSelected()
+// EndsWith tests if the string value end with the passed in value
+func (s *StringMatch) EndsWith(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Ends With", s.testName), func(we selenium.WebElement) error {
+		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if ok {
-			return errors.New("Element was selected")
+		if !strings.HasSuffix(val, match) {
+			return fmt.Errorf("The Element's %s does not end with '%s'. Got '%s'", s.testName, match, s.got(val, match))
 		}
 		return nil
 	})
 }
 
-// StringMatch is for testing the value of strings in elements
-type StringMatch struct {
-	testName string
-	value    func(selenium.WebElement) (string, error)
-	e        *Elements
-}
-
-// Equals tests if the string value matches the passed in value exactly
-func (s *StringMatch) Equals(match string) *Elements {
-	return s.e.test(fmt.Sprintf("%s Equals", s.testName), func(we selenium.WebElement) error {
+// Regexp tests if the string value matches the regular expression
+func (s *StringMatch) Regexp(exp *regexp.Regexp) *Elements {
+	return s.test(fmt.Sprintf("%s Matches RegExp", s.testName), func(we selenium.WebElement) error {
 		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if val != match {
-			return fmt.Errorf("The element's %s does not equal '%s'. Got '%s'", s.testName, match, val)
+		if !exp.MatchString(val) {
+			return fmt.Errorf("The Element's %s does not match the regex '%s'.", s.testName, exp)
 		}
 		return nil
 	})
 }
 
-// Contains tests if the string value contains the passed in value
-func (s *StringMatch) Contains(match string) *Elements {
-	return s.e.test(fmt.Sprintf("%s Contains", s.testName), func(we selenium.WebElement) error {
+// NotEquals tests if the string value does not match the passed in value exactly
+func (s *StringMatch) NotEquals(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Not Equals", s.testName), func(we selenium.WebElement) error {
 		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if !strings.Contains(val, match) {
-			return fmt.Errorf("The Element's %s does not contain '%s'. Got '%s'", s.testName, match, val)
+		if val == match {
+			return fmt.Errorf("The element's %s equals '%s'. Got '%s'", s.testName, match, s.got(val, match))
 		}
 		return nil
 	})
 }
 
-// StartsWith tests if the string value starts with the passed in value
-func (s *StringMatch) StartsWith(match string) *Elements {
-	return s.e.test(fmt.Sprintf("%s Starts With", s.testName), func(we selenium.WebElement) error {
+// NotContains tests if the string value does not contain the passed in value
+func (s *StringMatch) NotContains(match string) *Elements {
+	return s.test(fmt.Sprintf("%s Not Contains", s.testName), func(we selenium.WebElement) error {
 		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if !strings.HasPrefix(val, match) {
-			return fmt.Errorf("The Element's %s does not start with '%s'. Got '%s'", s.testName, match, val)
+		if strings.Contains(val, match) {
+			return fmt.Errorf("The Element's %s contains '%s'. Got '%s'", s.testName, match, s.got(val, match))
 		}
 		return nil
 	})
 }
 
-// EndsWith tests if the string value end with the passed in value
-func (s *StringMatch) EndsWith(match string) *Elements {
-	return s.e.test(fmt.Sprintf("%s Ends With", s.testName), func(we selenium.WebElement) error {
+// NotRegexp tests if the string value does not match the regular expression
+func (s *StringMatch) NotRegexp(exp *regexp.Regexp) *Elements {
+	return s.test(fmt.Sprintf("%s Not Matches RegExp", s.testName), func(we selenium.WebElement) error {
 		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if !strings.HasSuffix(val, match) {
-			return fmt.Errorf("The Element's %s does not end with '%s'. Got '%s'", s.testName, match, val)
+		if exp.MatchString(val) {
+			return fmt.Errorf("The Element's %s matches the regex '%s'. Got '%s'", s.testName, exp,
+				s.got(val, ""))
 		}
 		return nil
 	})
 }
 
-// Regexp tests if the string value matches the regular expression
-func (s *StringMatch) Regexp(exp *regexp.Regexp) *Elements {
-	return s.e.test(fmt.Sprintf("%s Matches RegExp", s.testName), func(we selenium.WebElement) error {
+func (s *StringMatch) empty(testName string, trim, empty bool) *Elements {
+	defer s.e.AddCallerSkip(1).AddCallerSkip(-1)
+	return s.test(fmt.Sprintf("%s %s", s.testName, testName), func(we selenium.WebElement) error {
 		val, err := s.value(we)
 		if err != nil {
 			return err
 		}
-		if !exp.MatchString(val) {
-			return fmt.Errorf("The Element's %s does not match the regex '%s'.", s.testName, exp)
+		check := val
+		if trim {
+			check = strings.TrimSpace(check)
+		}
+		if empty && check != "" {
+			return fmt.Errorf("The Element's %s is not empty. Got '%s'", s.testName, s.got(val, ""))
+		}
+		if !empty && check == "" {
+			return fmt.Errorf("The Element's %s is empty. Got '%s'", s.testName, s.got(val, ""))
 		}
 		return nil
 	})
 }
 
+// Empty tests if the string value is empty
+func (s *StringMatch) Empty() *Elements {
+	return s.empty("Empty", false, true)
+}
+
+// NotEmpty tests if the string value is not empty
+func (s *StringMatch) NotEmpty() *Elements {
+	return s.empty("Not Empty", false, false)
+}
+
+// EmptyTrimmed tests if the string value is empty or only contains whitespace
+func (s *StringMatch) EmptyTrimmed() *Elements {
+	return s.empty("Empty Trimmed", true, true)
+}
+
+// NotEmptyTrimmed tests if the string value contains something other than whitespace
+func (s *StringMatch) NotEmptyTrimmed() *Elements {
+	return s.empty("Not Empty Trimmed", true, false)
+}
+
 // TagName tests if the elements match the given tag name
 func (e *Elements) TagName() *StringMatch {
 	return &StringMatch{
@@ -1035,6 +2877,83 @@ func (e *Elements) CSSProperty(property string) *StringMatch {
 	}
 }
 
+// InnerHTML tests the HTML inside the elements
+func (e *Elements) InnerHTML() *StringMatch {
+	return &StringMatch{
+		testName: "Inner HTML",
+		value: func(we selenium.WebElement) (string, error) {
+			return e.seq.html(we, "innerHTML")
+		},
+		html: true,
+		e:    e,
+	}
+}
+
+// OuterHTML tests the HTML of the elements, including the elements' own tags
+func (e *Elements) OuterHTML() *StringMatch {
+	return &StringMatch{
+		testName: "Outer HTML",
+		value: func(we selenium.WebElement) (string, error) {
+			return e.seq.html(we, "outerHTML")
+		},
+		html: true,
+		e:    e,
+	}
+}
+
+// html returns the element's innerHTML or outerHTML property, falling back to a script for drivers which don't
+// return them as attributes
+func (s *Sequence) html(we selenium.WebElement, property string) (string, error) {
+	html, err := we.GetAttribute(property)
+	if err == nil && (html != "" || property == "innerHTML") {
+		return html, nil
+	}
+	result, err := s.driver.ExecuteScript("return arguments[0]."+property+";", []interface{}{we})
+	if err != nil {
+		return "", err
+	}
+	html, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("Element's %s was a %T, not a string", property, result)
+	}
+	return html, nil
+}
+
+const valueScript = `
+var el = arguments[0];
+if (el.type === "checkbox" || el.type === "radio") {
+	return el.checked ? el.value : "";
+}
+if (el.value === undefined || el.value === null) {
+	return null;
+}
+return String(el.value);`
+
+// Value tests the current value of form controls, which can differ from the value attribute once the user has
+// typed into the control.  Selects have the value of their selected option, and checkboxes and radio buttons have
+// their value, usually "on", when checked and an empty value when unchecked
+func (e *Elements) Value() *StringMatch {
+	return &StringMatch{
+		testName: "Value",
+		value: func(we selenium.WebElement) (string, error) {
+			result, err := e.seq.driver.ExecuteScript(valueScript, []interface{}{we})
+			if err != nil {
+				return "", err
+			}
+			if result == nil {
+				return "", errors.New("Element has no value, only form controls such as inputs, selects and " +
+					"textareas have a value")
+			}
+			val, ok := result.(string)
+			if !ok {
+				return "", fmt.Errorf("Element's value was a %T, not a string", result)
+			}
+			return val, nil
+		},
+		e: e,
+	}
+}
+
 // Click sends a click to all of the elements
 func (e *Elements) Click() *Elements {
 	return e.test("Click", func(we selenium.WebElement) error {
@@ -1042,6 +2961,53 @@ func (e *Elements) Click() *Elements {
 	})
 }
 
+const scrollIntoViewScript = `arguments[0].scrollIntoView({block: "center", inline: "center"});`
+
+// ScrollIntoView scrolls the elements into the center of the viewport
+func (e *Elements) ScrollIntoView() *Elements {
+	return e.test("Scroll Into View", func(we selenium.WebElement) error {
+		_, err := e.seq.driver.ExecuteScript(scrollIntoViewScript, []interface{}{we})
+		return err
+	})
+}
+
+// ClickScrolled scrolls the elements into view before sending a click to them
+func (e *Elements) ClickScrolled() *Elements {
+	return e.test("Click Scrolled", func(we selenium.WebElement) error {
+		_, err := e.seq.driver.ExecuteScript(scrollIntoViewScript, []interface{}{we})
+		if err != nil {
+			return fmt.Errorf("Scrolling element into view failed: %s", err)
+		}
+		return we.Click()
+	})
+}
+
+// ClickWithModifier sends a click to the elements while holding down the passed in modifier keys
+// (i.e. selenium.ControlKey, selenium.ShiftKey).  The modifier keys are always released after the click
+func (e *Elements) ClickWithModifier(keys ...string) *Elements {
+	return e.test("Click With Modifier", func(we selenium.WebElement) (err error) {
+		var pressed []string
+		defer func() {
+			for i := len(pressed) - 1; i >= 0; i-- {
+				upErr := e.seq.driver.KeyUp(pressed[i])
+				if upErr != nil && err == nil {
+					err = fmt.Errorf("Releasing modifier key failed: %s", upErr)
+				}
+			}
+		}()
+
+		for i := range keys {
+			err = e.seq.driver.KeyDown(keys[i])
+			if err != nil {
+				return fmt.Errorf("Pressing modifier key failed: %s", err)
+			}
+			pressed = append(pressed, keys[i])
+		}
+
+		return we.Click()
+	})
+}
+
 // SendKeys sends a string of key to the elements
 func (e *Elements) SendKeys(keys string) *Elements {
 	return e.test("SendKeys", func(we selenium.WebElement) error {
@@ -1049,6 +3015,100 @@ func (e *Elements) SendKeys(keys string) *Elements {
 	})
 }
 
+// TypeSlowly sends the text to the elements one character at a time, waiting perKey between each character.
+// Useful for inputs which debounce keystrokes
+func (e *Elements) TypeSlowly(text string, perKey time.Duration) *Elements {
+	return e.test("Type Slowly", func(we selenium.WebElement) error {
+		sent := 0
+		for _, r := range text {
+			if sent > 0 {
+				time.Sleep(perKey)
+			}
+			err := we.SendKeys(string(r))
+			if err != nil {
+				return fmt.Errorf("Typing failed after %d of %d characters were sent: %s", sent,
+					utf8.RuneCountInString(text), err)
+			}
+			sent++
+		}
+		return nil
+	})
+}
+
+// SendKeysSecret sends a string of keys to the elements like SendKeys, but the keys are registered with
+// RedactValue so they are never output as part of an error or debug output
+func (e *Elements) SendKeysSecret(keys string) *Elements {
+	e.seq.RedactValue(keys)
+	return e.test("SendKeys Secret", func(we selenium.WebElement) error {
+		return we.SendKeys(keys)
+	})
+}
+
+// SetText clears the elements and then sends the passed in value as keys
+func (e *Elements) SetText(value string) *Elements {
+	return e.test("Set Text", func(we selenium.WebElement) error {
+		return setText(we, value, false)
+	})
+}
+
+// SetTextVerified clears the elements, sends the passed in value as keys, and then verifies that the elements'
+// value attribute equals what was typed
+func (e *Elements) SetTextVerified(value string) *Elements {
+	return e.test("Set Text Verified", func(we selenium.WebElement) error {
+		return setText(we, value, true)
+	})
+}
+
+func setText(we selenium.WebElement, value string, verify bool) error {
+	err := we.Clear()
+	if err != nil {
+		return fmt.Errorf("Clearing the element failed: %s", err)
+	}
+	err = we.SendKeys(value)
+	if err != nil {
+		return fmt.Errorf("Sending keys to the element failed: %s", err)
+	}
+	if !verify {
+		return nil
+	}
+	val, err := we.GetAttribute("value")
+	if err != nil {
+		return fmt.Errorf("Reading the element's value failed: %s", err)
+	}
+	if val != value {
+		return fmt.Errorf("The element's value does not equal '%s' after setting it. Got '%s'", value, val)
+	}
+	return nil
+}
+
+// PressKey sends a single key to the elements, i.e. selenium.EnterKey
+func (e *Elements) PressKey(key string) *Elements {
+	return e.test("Press Key", func(we selenium.WebElement) error {
+		return we.SendKeys(key)
+	})
+}
+
+// PressEnter sends the enter key to the elements
+func (e *Elements) PressEnter() *Elements {
+	return e.test("Press Enter", func(we selenium.WebElement) error {
+		return we.SendKeys(selenium.EnterKey)
+	})
+}
+
+// PressTab sends the tab key to the elements
+func (e *Elements) PressTab() *Elements {
+	return e.test("Press Tab", func(we selenium.WebElement) error {
+		return we.SendKeys(selenium.TabKey)
+	})
+}
+
+// PressEscape sends the escape key to the elements
+func (e *Elements) PressEscape() *Elements {
+	return e.test("Press Escape", func(we selenium.WebElement) error {
+		return we.SendKeys(selenium.EscapeKey)
+	})
+}
+
 // Submit sends a submit event to the elements
 func (e *Elements) Submit() *Elements {
 	return e.test("Submit", func(we selenium.WebElement) error {
@@ -1056,6 +3116,71 @@ func (e *Elements) Submit() *Elements {
 	})
 }
 
+// SubmitAndWait sends a submit event to the elements and then waits for the page to navigate
+func (e *Elements) SubmitAndWait() *Elements {
+	return e.test("Submit And Wait", func(we selenium.WebElement) error {
+		return e.seq.waitForNavigation(we.Submit)
+	})
+}
+
+// ClickAndWait sends a click to the elements and then waits for the page to navigate
+func (e *Elements) ClickAndWait() *Elements {
+	return e.test("Click And Wait", func(we selenium.WebElement) error {
+		return e.seq.waitForNavigation(we.Click)
+	})
+}
+
+// navigationMarkScript marks the current document, so a navigation can be seen even when the new page has the same
+// url, such as a form posting back to itself
+const navigationMarkScript = `window.__sequenceNavigation = true;`
+
+// navigatedScript returns whether the marked document has been replaced by a new one which has finished loading
+const navigatedScript = `return window.__sequenceNavigation !== true && document.readyState === "complete";`
+
+// waitForNavigation marks the current document, runs the action and then polls until either the url changes or the
+// marked document has been replaced by a loaded one, bounded by EventualTimeout
+func (s *Sequence) waitForNavigation(action func() error) error {
+	from, err := s.driver.CurrentURL()
+	if err != nil {
+		return err
+	}
+	_, err = s.driver.ExecuteScript(navigationMarkScript, nil)
+	if err != nil {
+		return err
+	}
+
+	err = action()
+	if err != nil {
+		return err
+	}
+
+	timeout := s.clampTimeout(s.EventualTimeout)
+	err = s.driver.WaitWithTimeoutAndInterval(func(d selenium.WebDriver) (bool, error) {
+		if err := s.waitCancelled(); err != nil {
+			return false, err
+		}
+		uri, err := d.CurrentURL()
+		if err != nil {
+			return false, nil
+		}
+		if uri != from {
+			return true, nil
+		}
+		navigated, err := d.ExecuteScript(navigatedScript, nil)
+		if err != nil {
+			return false, nil
+		}
+		return navigated == true, nil
+	}, timeout, s.EventualPoll)
+	if err != nil {
+		if s.Context().Err() != nil {
+			return err
+		}
+		return fmt.Errorf("The page never navigated away from %s within %s", from, timeout)
+	}
+	return nil
+}
+
 // Clear clears the elements
 func (e *Elements) Clear() *Elements {
 	return e.test("Clear", func(we selenium.WebElement) error {
@@ -1063,31 +3188,173 @@ func (e *Elements) Clear() *Elements {
 	})
 }
 
+// Check clicks the elements only if they aren't already selected, and verifies they are selected afterwards
+func (e *Elements) Check() *Elements {
+	return e.test("Check", func(we selenium.WebElement) error {
+		return setChecked(we, true)
+	})
+}
+
+// Uncheck clicks the elements only if they are selected, and verifies they are unselected afterwards
+func (e *Elements) Uncheck() *Elements {
+	return e.test("Uncheck", func(we selenium.WebElement) error {
+		return setChecked(we, false)
+	})
+}
+
+func setChecked(we selenium.WebElement, checked bool) error {
+	selected, err := we.IsSelected()
+	if err != nil {
+		return err
+	}
+	if selected == checked {
+		return nil
+	}
+	err = we.Click()
+	if err != nil {
+		return err
+	}
+	selected, err = we.IsSelected()
+	if err != nil {
+		return err
+	}
+	if selected != checked {
+		if checked {
+			return errors.New("Expected checkbox to become checked but it is still unchecked")
+		}
+		return errors.New("Expected checkbox to become unchecked but it is still checked")
+	}
+	return nil
+}
+
 // Filter filters out any elements for which the passed in function returns an error, useful for
-// matching elements by text contents, since they can't be selected for with css selectors
+// matching elements by text contents, since they can't be selected for with css selectors.  If every element is
+// filtered out, the errors of the first few are included in the next test's error
 func (e *Elements) Filter(fn func(we *Elements) error) *Elements {
-	if e.seq.err != nil {
+	return e.filterBy("", fn)
+}
+
+// filterBy filters the elements, recording how many elements the named filter started with and why they were
+// filtered out
+func (e *Elements) filterBy(name string, fn func(we *Elements) error) *Elements {
+	if e.seq.failed() {
 		return e
 	}
 
+	result := &filterResult{name: name}
+	e.filters = append(e.filters, result)
+
+	if e.selectFunc != nil {
+		// wrap the selection so re-selecting in Eventually re-applies the filter
+		selectFunc := e.selectFunc
+		e.selectFunc = func(selector string) ([]selenium.WebElement, error) {
+			elems, err := selectFunc(selector)
+			if err != nil {
+				return nil, err
+			}
+			return e.filter(elems, fn, result), nil
+		}
+	}
+
+	e.elems = e.filter(e.elems, fn, result)
+	return e
+}
+
+func (e *Elements) filter(elems []selenium.WebElement, fn func(we *Elements) error,
+	result *filterResult) []selenium.WebElement {
 	var filtered []selenium.WebElement
+	result.before = len(elems)
+	result.errs = nil
 
-	for i := range e.elems {
+	for i := range elems {
 		// run filter tests on copies of sequence and elements, so errors, and last funcs don't get propogated
 		we := &Elements{
 			seq: &Sequence{
 				driver:          e.seq.driver,
 				EventualPoll:    e.seq.EventualPoll,
 				EventualTimeout: e.seq.EventualTimeout,
+				ctx:             e.seq.ctx,
+				filtering:       true,
 			},
-			elems: []selenium.WebElement{e.elems[i]},
+			elems: []selenium.WebElement{elems[i]},
 		}
 		err := fn(we)
 		if err == nil {
-			filtered = append(filtered, e.elems[i])
+			filtered = append(filtered, elems[i])
+			continue
+		}
+		if len(result.errs) < maxFilterErrors {
+			if sErr, ok := err.(*Error); ok {
+				err = sErr.Err
+			}
+			result.errs = append(result.errs, fmt.Errorf("Element %d: %w", i+1, err))
 		}
 	}
 
-	e.elems = filtered
-	return e
+	result.after = len(filtered)
+	return filtered
+}
+
+// maxFilterErrors is how many of the errors from filtering out elements are shown when every element was filtered out
+const maxFilterErrors = 3
+
+// filterResult is how a filter narrowed down the elements the last time it was applied
+type filterResult struct {
+	name   string
+	before int
+	after  int
+	errs   []error
+}
+
+func (f *filterResult) String() string {
+	name := "the filter"
+	if f.name != "" {
+		name += " " + f.name
+	}
+	msg := fmt.Sprintf("the selector matched %d elements but %d passed %s", f.before, f.after, name)
+	for i := range f.errs {
+		msg += fmt.Sprintf("\n\t%s", f.errs[i])
+	}
+	if filteredOut := f.before - f.after; filteredOut > len(f.errs) {
+		msg += fmt.Sprintf("\n\t... and %d more", filteredOut-len(f.errs))
+	}
+	return msg
+}
+
+// noElements returns the error for when the selection is empty, explaining which filter removed the elements, if
+// any did
+func (e *Elements) noElements() error {
+	return fmt.Errorf("%w for the selector '%s'%s", ErrNoElements, e.selector, e.filterSummary())
+}
+
+// filterSummary describes the filter which removed every selected element, or returns an empty string if no filter
+// did
+func (e *Elements) filterSummary() string {
+	for i := range e.filters {
+		if e.filters[i].before > 0 && e.filters[i].after == 0 {
+			return ": " + e.filters[i].String()
+		}
+	}
+	return ""
+}
+
+// FilterByText filters out any elements whose text doesn't equal the passed in value
+func (e *Elements) FilterByText(match string) *Elements {
+	return e.filterBy(fmt.Sprintf("Text Equals '%s'", match), func(we *Elements) error {
+		return we.Text().Equals(match).End()
+	})
+}
+
+// FilterByTextContains filters out any elements whose text doesn't contain the passed in value
+func (e *Elements) FilterByTextContains(match string) *Elements {
+	return e.filterBy(fmt.Sprintf("Text Contains '%s'", match), func(we *Elements) error {
+		return we.Text().Contains(match).End()
+	})
+}
+
+// FilterByAttribute filters out any elements whose attribute doesn't equal the passed in value
+func (e *Elements) FilterByAttribute(attribute, value string) *Elements {
+	return e.filterBy(fmt.Sprintf("%s Attribute Equals '%s'", attribute, value), func(we *Elements) error {
+		return we.Attribute(attribute).Equals(value).End()
+	})
 }
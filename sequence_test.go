@@ -0,0 +1,2059 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tebeka/selenium"
+	"github.com/tebeka/selenium/log"
+)
+
+// fakeDriver is a WebDriver whose Wait calls the condition once and then always times out.  Only the methods
+// needed by the tests are implemented, calling any other will panic
+type fakeDriver struct {
+	selenium.WebDriver
+	elems      []selenium.WebElement
+	script     func(script string, args []interface{}) (interface{}, error)
+	screenshot []byte
+	url        string
+	cookies    []selenium.Cookie
+
+	scriptTimeouts []time.Duration
+}
+
+func (d *fakeDriver) Screenshot() ([]byte, error) {
+	return d.screenshot, nil
+}
+
+func (d *fakeDriver) ExecuteScript(script string, args []interface{}) (interface{}, error) {
+	return d.script(script, args)
+}
+
+func (d *fakeDriver) WaitWithTimeoutAndInterval(condition selenium.Condition, timeout,
+	interval time.Duration) error {
+	_, err := condition(d)
+	if err != nil {
+		return err
+	}
+	return errors.New("timeout")
+}
+
+func (d *fakeDriver) Title() (string, error) {
+	return "Fake", nil
+}
+
+func (d *fakeDriver) PageSource() (string, error) {
+	return "<html></html>", nil
+}
+
+func (d *fakeDriver) Log(typ log.Type) ([]log.Message, error) {
+	return nil, errors.New("not supported")
+}
+
+func (d *fakeDriver) SetAsyncScriptTimeout(timeout time.Duration) error {
+	d.scriptTimeouts = append(d.scriptTimeouts, timeout)
+	return nil
+}
+
+func (d *fakeDriver) ExecuteScriptAsync(script string, args []interface{}) (interface{}, error) {
+	return d.script(script, args)
+}
+
+func (d *fakeDriver) GetCookies() ([]selenium.Cookie, error) {
+	return d.cookies, nil
+}
+
+func (d *fakeDriver) SessionID() string {
+	return "fake"
+}
+
+func (d *fakeDriver) CurrentURL() (string, error) {
+	if d.url != "" {
+		return d.url, nil
+	}
+	return "http://localhost/fake", nil
+}
+
+func (d *fakeDriver) FindElements(by, value string) ([]selenium.WebElement, error) {
+	return d.elems, nil
+}
+
+// fakeElement is a WebElement whose display state is returned from its displayed function
+type fakeElement struct {
+	selenium.WebElement
+	displayed func() bool
+	text      string
+	children  map[string][]selenium.WebElement
+	attrs     map[string]string
+	size      selenium.Size
+	location  selenium.Point
+	findErr   error
+}
+
+func (e *fakeElement) IsDisplayed() (bool, error) {
+	return e.displayed(), nil
+}
+
+func (e *fakeElement) FindElements(by, value string) ([]selenium.WebElement, error) {
+	if e.findErr != nil {
+		return nil, e.findErr
+	}
+	return e.children[value], nil
+}
+
+func (e *fakeElement) Size() (*selenium.Size, error) {
+	return &e.size, nil
+}
+
+func (e *fakeElement) Location() (*selenium.Point, error) {
+	return &e.location, nil
+}
+
+func (e *fakeElement) TagName() (string, error) {
+	return "div", nil
+}
+
+func (e *fakeElement) Text() (string, error) {
+	return e.text, nil
+}
+
+func (e *fakeElement) GetAttribute(name string) (string, error) {
+	if e.attrs != nil {
+		return e.attrs[name], nil
+	}
+	return "fake", nil
+}
+
+// flaky returns a function which fails the first time it is called, and passes every time after that
+func flaky() func() bool {
+	calls := 0
+	return func() bool {
+		calls++
+		return calls > 1
+	}
+}
+
+func TestEventuallyTimeoutWithoutError(t *testing.T) {
+	passes := flaky()
+	err := Start(&fakeDriver{}).Test("Flaky", func(d selenium.WebDriver) error {
+		if !passes() {
+			return errors.New("not yet")
+		}
+		return nil
+	}).Eventually().End()
+
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Stage != "Eventually Timeout" {
+		t.Fatalf("Expected stage 'Eventually Timeout', got '%s'", sErr.Stage)
+	}
+	if !strings.Contains(sErr.Err.Error(), "timeout") {
+		t.Fatalf("Expected the driver's timeout error to be included, got '%s'", sErr.Err)
+	}
+	if sErr.Caller == "" {
+		t.Fatalf("Expected the error to have a caller")
+	}
+}
+
+func TestEventuallyTimeoutWithError(t *testing.T) {
+	err := Start(&fakeDriver{}).Test("Failing", func(d selenium.WebDriver) error {
+		return errors.New("still failing")
+	}).Eventually().End()
+
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Stage != "Failing" {
+		t.Fatalf("Expected stage 'Failing', got '%s'", sErr.Stage)
+	}
+	if !strings.Contains(sErr.Err.Error(), "still failing") {
+		t.Fatalf("Expected the step's error to be included, got '%s'", sErr.Err)
+	}
+}
+
+func TestElementsEventuallyTimeoutWithoutError(t *testing.T) {
+	driver := &fakeDriver{
+		elems: []selenium.WebElement{&fakeElement{displayed: flaky()}},
+	}
+	err := Start(driver).Find("#flaky").Visible().Eventually().End()
+
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Stage != "Eventually Timeout" {
+		t.Fatalf("Expected stage 'Eventually Timeout', got '%s'", sErr.Stage)
+	}
+	if !strings.Contains(sErr.Err.Error(), "Element was not visible") {
+		t.Fatalf("Expected the last step error to be included, got '%s'", sErr.Err)
+	}
+}
+
+func TestSoftCollectsErrors(t *testing.T) {
+	ran := false
+	err := Start(&fakeDriver{}).Soft().
+		Test("First", func(d selenium.WebDriver) error {
+			return errors.New("first failed")
+		}).
+		Test("Second", func(d selenium.WebDriver) error {
+			return errors.New("second failed")
+		}).
+		Test("Third", func(d selenium.WebDriver) error {
+			ran = true
+			return nil
+		}).End()
+
+	if !ran {
+		t.Fatalf("Expected the sequence to keep running after a failure in soft mode")
+	}
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("Expected Errors, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %s", len(errs), errs)
+	}
+}
+
+func TestHardStopsAfterSoft(t *testing.T) {
+	ran := false
+	err := Start(&fakeDriver{}).Soft().
+		Test("Soft", func(d selenium.WebDriver) error {
+			return errors.New("soft failed")
+		}).
+		Hard().
+		Test("Hard", func(d selenium.WebDriver) error {
+			return errors.New("hard failed")
+		}).
+		Test("Skipped", func(d selenium.WebDriver) error {
+			ran = true
+			return nil
+		}).End()
+
+	if ran {
+		t.Fatalf("Expected the sequence to stop after a failure once Hard was called")
+	}
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("Expected Errors, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %s", len(errs), errs)
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	err := Start(&fakeDriver{}).Find("#missing").Visible().End()
+	if !errors.Is(err, ErrNoElements) {
+		t.Fatalf("Expected ErrNoElements, got %v", err)
+	}
+	var sErr *Error
+	if !errors.As(err, &sErr) {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Selector != "#missing" {
+		t.Fatalf("Expected selector '#missing', got '%s'", sErr.Selector)
+	}
+
+	driver := &fakeDriver{
+		elems: []selenium.WebElement{&fakeElement{displayed: func() bool { return false }}},
+	}
+	err = Start(driver).Find("#hidden").Visible().Eventually().End()
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestErrorJSON(t *testing.T) {
+	seq := Start(&fakeDriver{}).RedactValue("secret").Test("Failing", func(d selenium.WebDriver) error {
+		return errors.New("bad secret")
+	})
+	if seq.End() == nil {
+		t.Fatalf("Expected the sequence to fail")
+	}
+
+	data, err := json.Marshal(seq.ErrorDetails())
+	if err != nil {
+		t.Fatalf("Error marshalling the error: %s", err)
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		t.Fatalf("Error unmarshalling the error: %s", err)
+	}
+	if result["stage"] != "Failing" {
+		t.Fatalf("Expected stage 'Failing', got %v", result["stage"])
+	}
+	if result["error"] != "bad ********" {
+		t.Fatalf("Expected a redacted error message, got %v", result["error"])
+	}
+	if result["url"] != "http://localhost/fake" {
+		t.Fatalf("Expected the page's url, got %v", result["url"])
+	}
+	if _, ok := result["timestamp"]; !ok {
+		t.Fatalf("Expected a timestamp")
+	}
+}
+
+func TestMultipleErrorHandlers(t *testing.T) {
+	var called []int
+	err := Start(&fakeDriver{}).
+		OnError(func(err Error, s *Sequence) {
+			called = append(called, 1)
+			panic("handler failed")
+		}).
+		OnError(func(err Error, s *Sequence) {
+			called = append(called, 2)
+		}).
+		Test("Failing", func(d selenium.WebDriver) error {
+			return errors.New("failed")
+		}).End()
+
+	if len(called) != 2 || called[0] != 1 || called[1] != 2 {
+		t.Fatalf("Expected both handlers to be called in order, got %v", called)
+	}
+	if err == nil || !strings.Contains(err.Error(), "handler failed") {
+		t.Fatalf("Expected the handler's panic to be reported with the error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Failing") {
+		t.Fatalf("Expected the original error to be kept, got %v", err)
+	}
+}
+
+// fakeTB records the failures reported to it, without stopping the running test
+type fakeTB struct {
+	testing.TB
+	errors []string
+	logs   []string
+	failed bool
+}
+
+func (tb *fakeTB) Helper()      {}
+func (tb *fakeTB) Name() string { return "TestFake" }
+func (tb *fakeTB) FailNow()     { tb.failed = true }
+
+func (tb *fakeTB) Logf(format string, args ...interface{}) {}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestOkReportsEachError(t *testing.T) {
+	tb := &fakeTB{}
+	Start(&fakeDriver{}).Soft().
+		Test("First", func(d selenium.WebDriver) error {
+			return errors.New("first failed")
+		}).
+		Test("Second", func(d selenium.WebDriver) error {
+			return errors.New("second failed")
+		}).Ok(tb)
+
+	if !tb.failed {
+		t.Fatalf("Expected the test to be failed")
+	}
+	if len(tb.errors) != 2 {
+		t.Fatalf("Expected 2 errors to be reported, got %d", len(tb.errors))
+	}
+	if !strings.Contains(tb.errors[0], "http://localhost/fake") {
+		t.Fatalf("Expected the error to include the page's url, got '%s'", tb.errors[0])
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	buff := &bytes.Buffer{}
+	passes := flaky()
+	Start(&pollingDriver{polls: 3}).Verbose(buff).
+		Test("Passing", func(d selenium.WebDriver) error {
+			return nil
+		}).
+		Test("Flaky", func(d selenium.WebDriver) error {
+			if !passes() {
+				return errors.New("not yet")
+			}
+			return nil
+		}).Eventually().
+		Title().Equals("Fake").
+		Find("select").Options().End()
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 lines to be logged, got %d: %s", len(lines), buff)
+	}
+	if !strings.HasPrefix(lines[0], "PASS  Passing") {
+		t.Fatalf("Expected the passing step to be logged, got '%s'", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "FAIL  Flaky") {
+		t.Fatalf("Expected the flaky step's failure to be logged, got '%s'", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "PASS  Flaky (retry 1)") {
+		t.Fatalf("Expected the flaky step's retry to be logged, got '%s'", lines[2])
+	}
+	if !strings.Contains(lines[0], "sequence_test.go") {
+		t.Fatalf("Expected the step's caller to be logged, got '%s'", lines[0])
+	}
+	if !strings.HasPrefix(lines[3], "PASS  Title Equals") {
+		t.Fatalf("Expected the title step to be logged, got '%s'", lines[3])
+	}
+	if !strings.HasPrefix(lines[5], "PASS  Options 'select option'") || !strings.Contains(lines[5], "sequence_test.go") {
+		t.Fatalf("Expected the traversal to be logged with its caller, got '%s'", lines[5])
+	}
+}
+
+func TestTimings(t *testing.T) {
+	passes := flaky()
+	seq := Start(&fakeDriver{}).RecordTimings().
+		Test("Passing", func(d selenium.WebDriver) error {
+			return nil
+		}).
+		Test("Flaky", func(d selenium.WebDriver) error {
+			if !passes() {
+				return errors.New("not yet")
+			}
+			return nil
+		}).Eventually()
+	seq.End()
+
+	timings := seq.Timings()
+	if len(timings) != 2 {
+		t.Fatalf("Expected 2 timings, got %d", len(timings))
+	}
+	if timings[0].Name != "Passing" || !timings[0].Passed || timings[0].Attempts != 1 {
+		t.Fatalf("Unexpected timing for the passing step: %+v", timings[0])
+	}
+	if timings[1].Name != "Flaky" || timings[1].Attempts != 2 || len(timings[1].AttemptDurations) != 2 {
+		t.Fatalf("Expected the flaky step's retry to be included in its timing: %+v", timings[1])
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	buff := &bytes.Buffer{}
+	passes := flaky()
+	Start(&fakeDriver{}).WithReporter(NewJUnitReporter(buff, "TestJUnit")).
+		Test("Passing", func(d selenium.WebDriver) error {
+			return nil
+		}).
+		Test("Flaky", func(d selenium.WebDriver) error {
+			if !passes() {
+				return errors.New("not yet")
+			}
+			return nil
+		}).Eventually().End()
+
+	result := struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Cases    []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"testcase"`
+	}{}
+	err := xml.Unmarshal(buff.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Error parsing report: %s", err)
+	}
+	if result.Tests != 2 || result.Failures != 0 {
+		t.Fatalf("Expected 2 tests with no failures, got %d tests with %d failures: %s", result.Tests,
+			result.Failures, buff)
+	}
+	if !strings.HasPrefix(result.Cases[1].Name, "Flaky") {
+		t.Fatalf("Expected the retried step to replace its failed attempt, got %s", buff)
+	}
+
+	buff.Reset()
+	Start(&fakeDriver{}).WithReporter(NewJUnitReporter(buff, "TestJUnit")).
+		Test("Failing", func(d selenium.WebDriver) error {
+			return errors.New("failed")
+		}).End()
+	err = xml.Unmarshal(buff.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Error parsing report: %s", err)
+	}
+	if result.Tests != 1 || result.Failures != 1 {
+		t.Fatalf("Expected 1 test with 1 failure, got %d tests with %d failures: %s", result.Tests,
+			result.Failures, buff)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	ran := false
+	err := Start(&fakeDriver{}).
+		Group("check out", func(s *Sequence) *Sequence {
+			return s.Group("payment", func(s *Sequence) *Sequence {
+				return s.Test("Pay", func(d selenium.WebDriver) error {
+					return errors.New("declined")
+				})
+			})
+		}).
+		Test("After", func(d selenium.WebDriver) error {
+			ran = true
+			return nil
+		}).End()
+
+	if ran {
+		t.Fatalf("Expected an error in a group to stop the sequence")
+	}
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Group != "check out > payment" {
+		t.Fatalf("Expected group 'check out > payment', got '%s'", sErr.Group)
+	}
+	if sErr.Stage != "check out > payment > Pay" {
+		t.Fatalf("Expected the stage to be prefixed with the group, got '%s'", sErr.Stage)
+	}
+}
+
+func failingStep(s *Sequence) *Sequence {
+	defer s.AddCallerSkip(1).AddCallerSkip(-1)
+	return s.Test("Wrapped", func(d selenium.WebDriver) error {
+		return errors.New("failed")
+	})
+}
+
+func TestAddCallerSkip(t *testing.T) {
+	s := Start(&fakeDriver{})
+	_, _, line, _ := runtime.Caller(0)
+	err := failingStep(s).End()
+
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if !strings.HasSuffix(sErr.Caller, fmt.Sprintf(":%d", line+1)) {
+		t.Fatalf("Expected the caller to be the line calling the wrapper, got %s", sErr.Caller)
+	}
+	if s.callerSkip != 0 {
+		t.Fatalf("Expected the caller skip to be removed, got %d", s.callerSkip)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var ran []interface{}
+	err := Start(&fakeDriver{}).
+		ForEach("login", []interface{}{"alice", "bob", "carol"}, func(s *Sequence, c interface{}) *Sequence {
+			return s.Test("Sign In", func(d selenium.WebDriver) error {
+				ran = append(ran, c)
+				if c == "bob" {
+					return errors.New("bad password")
+				}
+				return nil
+			})
+		}).End()
+
+	if len(ran) != 2 {
+		t.Fatalf("Expected the cases to stop at the first failure, ran %v", ran)
+	}
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Group != "login #1 (bob)" {
+		t.Fatalf("Expected the error to be labeled with the case, got '%s'", sErr.Group)
+	}
+}
+
+func TestForEachAll(t *testing.T) {
+	err := Start(&fakeDriver{}).
+		ForEachAll("login", []interface{}{"alice", "bob", "carol"}, func(s *Sequence, c interface{}) *Sequence {
+			return s.Test("Sign In", func(d selenium.WebDriver) error {
+				if c != "bob" {
+					return fmt.Errorf("no account for %s", c)
+				}
+				return nil
+			})
+		}).End()
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("Expected the failures to be aggregated, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d", len(errs))
+	}
+	first, second := errs[0].(*Error), errs[1].(*Error)
+	if first.Group != "login #0 (alice)" || second.Group != "login #2 (carol)" {
+		t.Fatalf("Expected the errors to be labeled with their cases, got '%s' and '%s'", first.Group,
+			second.Group)
+	}
+}
+
+func TestIfElement(t *testing.T) {
+	hidden := &fakeElement{displayed: func() bool { return false }}
+	shown := &fakeElement{displayed: func() bool { return true }}
+
+	ran := false
+	err := Start(&fakeDriver{elems: []selenium.WebElement{hidden}}).
+		IfElement("#consent", func(s *Sequence) *Sequence {
+			ran = true
+			return s
+		}).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ran {
+		t.Fatalf("Expected the branch not to run when the element isn't visible")
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{hidden, shown}}).
+		IfElement("#consent", func(s *Sequence) *Sequence {
+			ran = true
+			return s
+		}).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !ran {
+		t.Fatalf("Expected the branch to run when the element is visible")
+	}
+}
+
+func TestIf(t *testing.T) {
+	took := ""
+	err := Start(&fakeDriver{}).
+		If(func(d selenium.WebDriver) (bool, error) {
+			return false, nil
+		}, func(s *Sequence) *Sequence {
+			took = "then"
+			return s
+		}, func(s *Sequence) *Sequence {
+			took = "otherwise"
+			return s
+		}).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if took != "otherwise" {
+		t.Fatalf("Expected the otherwise branch to run, got '%s'", took)
+	}
+
+	err = Start(&fakeDriver{}).
+		If(func(d selenium.WebDriver) (bool, error) {
+			return false, errors.New("no cookie")
+		}, nil, nil).End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Condition" {
+		t.Fatalf("Expected a Condition error, got %v", err)
+	}
+}
+
+func TestInto(t *testing.T) {
+	one := &fakeElement{displayed: func() bool { return true }}
+
+	var id string
+	err := Start(&fakeDriver{elems: []selenium.WebElement{one}}).
+		Find("#order").Attribute("data-id").Into(&id).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if id != "fake" {
+		t.Fatalf("Expected the attribute to be captured, got '%s'", id)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{one, one}}).
+		Find(".order").All().Attribute("data-id").Into(&id).End()
+	if !errors.Is(err, ErrCaptureMultipleElements) {
+		t.Fatalf("Expected capturing from multiple elements to fail, got %v", err)
+	}
+
+	var ids []string
+	err = Start(&fakeDriver{elems: []selenium.WebElement{one, one}}).
+		Find(".order").Attribute("data-id").IntoSlice(&ids).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("Expected 2 captured values, got %v", ids)
+	}
+}
+
+func TestEach(t *testing.T) {
+	one := &fakeElement{displayed: func() bool { return true }}
+
+	var seen []int
+	bold := func(i int, we selenium.WebElement) error {
+		seen = append(seen, i)
+		if i == 1 {
+			return errors.New("bold")
+		}
+		return nil
+	}
+	err := Start(&fakeDriver{elems: []selenium.WebElement{one, one, one}}).Find("tr").Each("Bold", bold).End()
+
+	if len(seen) != 2 {
+		t.Fatalf("Expected Each to stop at the first failure, saw %v", seen)
+	}
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Element != one || !strings.Contains(sErr.Err.Error(), "Element 2 of 3") {
+		t.Fatalf("Expected the error to report the failing element, got %s", sErr)
+	}
+
+	err = Start(&fakeDriver{}).Find("tr").Each("Bold", bold).End()
+	if !errors.Is(err, ErrNoElements) {
+		t.Fatalf("Expected an empty selection to fail, got %v", err)
+	}
+}
+
+func TestAttributeValues(t *testing.T) {
+	one := &fakeElement{displayed: func() bool { return true }}
+
+	var vals []string
+	err := Start(&fakeDriver{elems: []selenium.WebElement{one, one}}).
+		Find("li").AttributeValues("data-id", &vals).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(vals) != 2 || vals[0] != "fake" {
+		t.Fatalf("Expected a value for each element, got %v", vals)
+	}
+
+	vals = nil
+	err = Start(&fakeDriver{}).Find("li").AttributeValues("data-id", &vals).Count(0).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if vals == nil || len(vals) != 0 {
+		t.Fatalf("Expected an empty slice for an empty selection, got %#v", vals)
+	}
+}
+
+func TestWebElements(t *testing.T) {
+	one := &fakeElement{displayed: func() bool { return true }}
+	e := Start(&fakeDriver{elems: []selenium.WebElement{one}}).Find("#order")
+
+	elems := e.WebElements()
+	if len(elems) != 1 || elems[0] != one {
+		t.Fatalf("Expected the selected elements, got %v", elems)
+	}
+	elems[0] = nil
+	if e.WebElements()[0] != one {
+		t.Fatalf("Expected changing the returned slice not to affect the elements")
+	}
+	if e.Selector() != "#order" {
+		t.Fatalf("Expected selector '#order', got '%s'", e.Selector())
+	}
+	if e.Err() != nil {
+		t.Fatalf("Unexpected error: %s", e.Err())
+	}
+}
+
+// textElements returns fake elements with the passed in text
+func textElements(texts ...string) []selenium.WebElement {
+	elems := make([]selenium.WebElement, len(texts))
+	for i := range texts {
+		elems[i] = &fakeElement{text: texts[i]}
+	}
+	return elems
+}
+
+func TestTextOrdered(t *testing.T) {
+	err := Start(&fakeDriver{elems: textElements("apple", "banana", "cherry")}).
+		Find("td").TextAscending().End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(&fakeDriver{elems: textElements("9", "10", "2")}).
+		Find("td").TextAscendingNumeric().End()
+	if err == nil || !strings.Contains(err.Error(), "Elements 2 and 3 are out of order: '10' then '2'") {
+		t.Fatalf("Expected the first out of order pair to be reported, got %v", err)
+	}
+
+	descendingFold := func(a, b string) bool {
+		return strings.ToLower(a) >= strings.ToLower(b)
+	}
+	err = Start(&fakeDriver{elems: textElements("b", "A")}).Find("td").TextOrdered(descendingFold).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := &fakeElement{children: map[string][]selenium.WebElement{
+		":scope > thead > tr > th": textElements("Name", "Status"),
+		":scope > tbody > tr": {
+			&fakeElement{children: map[string][]selenium.WebElement{
+				":scope > th, :scope > td": textElements("alice", "active"),
+			}},
+			&fakeElement{children: map[string][]selenium.WebElement{
+				":scope > th, :scope > td": textElements("bob", "locked"),
+			}},
+		},
+	}}
+
+	err := Start(&fakeDriver{elems: []selenium.WebElement{table}}).
+		Find("table").AsTable().RowCount(2).
+		And().Find("table").AsTable().CellByHeader(1, "Status").Text().Equals("locked").
+		And().Find("table").AsTable().Cell(0, 0).Text().Equals("alice").
+		End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{table}}).
+		Find("table").AsTable().CellByHeader(0, "Email").End()
+	if err == nil || !strings.Contains(err.Error(), `Available headers: ["Name" "Status"]`) {
+		t.Fatalf("Expected the available headers to be listed, got %v", err)
+	}
+
+	tables := Start(&fakeDriver{elems: []selenium.WebElement{table, table}}).Find("table").AsTable()
+	_, _, line, _ := runtime.Caller(0)
+	err = tables.RowCount(2).End()
+	sErr, ok := err.(*Error)
+	if !ok || !strings.Contains(sErr.Error(), "matched 2 elements, expected a single table") {
+		t.Fatalf("Expected a single table error, got %v", err)
+	}
+	if !strings.HasSuffix(sErr.Caller, fmt.Sprintf("sequence_test.go:%d", line+1)) {
+		t.Fatalf("Expected the caller to be the line calling RowCount, got %s", sErr.Caller)
+	}
+}
+
+func TestHasClass(t *testing.T) {
+	tab := &fakeElement{attrs: map[string]string{
+		"class":     "tab  inactive\tlarge",
+		"outerHTML": "<li class=\"tab  inactive\tlarge\"></li>",
+	}}
+
+	err := Start(&fakeDriver{elems: []selenium.WebElement{tab}}).
+		Find(".tab").HasClass("tab", "large").NotHasClass("active").End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{tab}}).Find(".tab").HasClass("active").End()
+	if err == nil || !strings.Contains(err.Error(), "Classes: 'tab  inactive\tlarge'") {
+		t.Fatalf("Expected the element's classes in the error, got %v", err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	input := &fakeElement{}
+	values := map[selenium.WebElement]interface{}{input: "x@y.z"}
+	d := &fakeDriver{
+		elems: []selenium.WebElement{input},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return values[args[0].(selenium.WebElement)], nil
+		},
+	}
+
+	err := Start(d).Find("#email").Value().Equals("x@y.z").End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	values[input] = nil
+	err = Start(d).Find("#email").Value().Equals("x@y.z").End()
+	if err == nil || !strings.Contains(err.Error(), "Element has no value") {
+		t.Fatalf("Expected an error for an element without a value, got %v", err)
+	}
+}
+
+func TestInnerHTML(t *testing.T) {
+	editor := &fakeElement{attrs: map[string]string{
+		"innerHTML": "<p>" + strings.Repeat("a", 100) + "<em>bold</em>" + strings.Repeat("b", 100) + "</p>",
+	}}
+
+	err := Start(&fakeDriver{elems: []selenium.WebElement{editor}}).HTMLMatchLimit(20).
+		Find("#editor").InnerHTML().Equals("<p>" + strings.Repeat("a", 100) + "<strong>bold</strong>").End()
+	if err == nil {
+		t.Fatalf("Expected the HTML not to match")
+	}
+	if !strings.Contains(err.Error(), "Got '...aaaaaaaaa<em>bold</e...'") {
+		t.Fatalf("Expected the HTML to be truncated around the mismatch, got %s", err)
+	}
+}
+
+func TestHasAttribute(t *testing.T) {
+	button := &fakeElement{}
+	attrs := map[string]interface{}{"disabled": ""}
+	d := &fakeDriver{
+		elems: []selenium.WebElement{button},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return attrs[args[1].(string)], nil
+		},
+	}
+
+	err := Start(d).Find("button").HasAttribute("disabled").NoAttribute("required").End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(d).Find("button").NoAttribute("disabled").End()
+	if err == nil || !strings.Contains(err.Error(), "Element has the attribute 'disabled' with the value ''") {
+		t.Fatalf("Expected the present attribute to be reported, got %v", err)
+	}
+
+	err = Start(d).Find("button").HasAttribute("required").End()
+	if err == nil || !strings.Contains(err.Error(), "Element does not have the attribute 'required'") {
+		t.Fatalf("Expected the absent attribute to be reported, got %v", err)
+	}
+}
+
+func TestEqualsColor(t *testing.T) {
+	for _, c := range []string{"#ff0000", "#F00", "#ff0000ff", "red", "rgb(255, 0, 0)", "rgb(100% 0% 0%)"} {
+		got, err := parseColor(c)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing '%s': %s", c, err)
+		}
+		if got != (cssColor{r: 255, a: 1}) {
+			t.Fatalf("Expected '%s' to be red, got %s", c, got)
+		}
+	}
+
+	swatch := &fakeElement{attrs: map[string]string{"color": "rgba(254, 0, 1, 1)", "outerHTML": "<span></span>"}}
+	err := Start(&fakeDriver{elems: []selenium.WebElement{swatch}}).
+		Find(".swatch").Attribute("color").EqualsColorWithin("#ff0000", 1).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	color := Start(&fakeDriver{elems: []selenium.WebElement{swatch}}).Find(".swatch").Attribute("color")
+	_, _, line, _ := runtime.Caller(0)
+	err = color.EqualsColor("rgba(255, 0, 0, 0.5)").End()
+	if err == nil || !strings.Contains(err.Error(), "does not equal the color 'rgba(255, 0, 0, 0.5)'") {
+		t.Fatalf("Expected the colors not to match, got %v", err)
+	}
+	if sErr := err.(*Error); !strings.HasSuffix(sErr.Caller, fmt.Sprintf("sequence_test.go:%d", line+1)) {
+		t.Fatalf("Expected the caller to be the line calling EqualsColor, got %s", sErr.Caller)
+	}
+}
+
+func TestAsTime(t *testing.T) {
+	loc := time.FixedZone("NZDT", 13*60*60)
+	stamp := &fakeElement{text: "Jan 2, 2024 3:04 PM"}
+	posted := time.Date(2024, 1, 2, 2, 4, 30, 0, time.UTC)
+
+	err := Start(&fakeDriver{elems: []selenium.WebElement{stamp}}).
+		Find(".posted").Text().AsTimeIn("Jan 2, 2006 3:04 PM", loc).Equals(posted, time.Minute).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{stamp}}).
+		Find(".posted").Text().AsTime("2006-01-02").Before(posted).End()
+	if err == nil || !strings.Contains(err.Error(), "'Jan 2, 2024 3:04 PM' is not a time in the layout '2006-01-02'") {
+		t.Fatalf("Expected a parse error with the raw text and layout, got %v", err)
+	}
+}
+
+func TestAsJSON(t *testing.T) {
+	widget := &fakeElement{attrs: map[string]string{
+		"data-config": `{"items": [{"name": "first", "count": 2}], "enabled": true}`,
+		"data-broken": `{"items": [}`,
+		"outerHTML":   "<div></div>",
+	}}
+
+	err := Start(&fakeDriver{elems: []selenium.WebElement{widget}}).
+		Find(".widget").Attribute("data-config").AsJSON().HasKey("items.0.name").
+		Attribute("data-config").AsJSON().ValueEquals("items.0", map[string]interface{}{"name": "first", "count": 2}).
+		Attribute("data-config").AsJSON().ValueEquals("enabled", true).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{widget}}).
+		Find(".widget").Attribute("data-config").AsJSON().HasKey("items.1").End()
+	if err == nil || !strings.Contains(err.Error(), "'items' is an array of 1 elements with no index '1'") {
+		t.Fatalf("Expected a missing key error, got %v", err)
+	}
+
+	err = Start(&fakeDriver{elems: []selenium.WebElement{widget}}).
+		Find(".widget").Attribute("data-broken").AsJSON().Equals(nil).End()
+	if err == nil || !strings.Contains(err.Error(), "is not valid JSON") {
+		t.Fatalf("Expected a parse error, got %v", err)
+	}
+}
+
+func TestAccessibleName(t *testing.T) {
+	d := &fakeDriver{
+		elems: []selenium.WebElement{&fakeElement{}},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return []interface{}{"Save", "from aria-label"}, nil
+		},
+	}
+
+	err := Start(d).Find("button").AccessibleName().Equals("Submit").End()
+	if err == nil || !strings.Contains(err.Error(), "Got 'Save' (accessible name from aria-label)") {
+		t.Fatalf("Expected the source of the name in the error, got %v", err)
+	}
+
+	err = Start(d).Find("img").ImagesHaveAlt().End()
+	if err == nil || !strings.Contains(err.Error(), "Element is a <div>, not an <img>") {
+		t.Fatalf("Expected non images to fail, got %v", err)
+	}
+}
+
+func TestFocused(t *testing.T) {
+	field := &fakeElement{}
+	focused := false
+	d := &fakeDriver{
+		elems: []selenium.WebElement{field},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return focused, nil
+		},
+	}
+
+	err := Start(d).Find("#name").Focused().End()
+	if err == nil || !strings.Contains(err.Error(), "Element does not have focus") {
+		t.Fatalf("Expected an unfocused element to fail, got %v", err)
+	}
+
+	focused = true
+	err = Start(d).Find("#name").Focused().End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInViewport(t *testing.T) {
+	rect := []interface{}{10.0, 700.0, 200.0, 100.0, 1024.0, 768.0}
+	d := &fakeDriver{
+		elems: []selenium.WebElement{&fakeElement{}},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return rect, nil
+		},
+	}
+
+	err := Start(d).Find(".card").InViewport().End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(d).Find(".card").FullyInViewport().End()
+	if err == nil || !strings.Contains(err.Error(), "rect (left 10, top 700, 200x100) and the viewport is 1024x768") {
+		t.Fatalf("Expected the rect and viewport in the error, got %v", err)
+	}
+
+	rect[1] = 900.0
+	err = Start(d).Find(".card").NotInViewport().End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestSize(t *testing.T) {
+	hero := &fakeElement{size: selenium.Size{Width: 599, Height: 300}}
+	d := &fakeDriver{elems: []selenium.WebElement{hero}}
+
+	err := Start(d).Find(".hero").Size().WidthAtLeast(600).End()
+	if err == nil || !strings.Contains(err.Error(), "The element's width is not at least 600px. Got 599px (599x300)") {
+		t.Fatalf("Expected the width to be too small, got %v", err)
+	}
+
+	err = Start(d).Find(".hero").Size().Tolerance(1).WidthAtLeast(600).Size().HeightEquals(300).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestAlignedWith(t *testing.T) {
+	label := &fakeElement{location: selenium.Point{X: 20, Y: 100}}
+	input := &fakeElement{location: selenium.Point{X: 21, Y: 140}}
+
+	s := Start(&fakeDriver{elems: []selenium.WebElement{label}})
+	other := s.Find("label")
+	other.elems = []selenium.WebElement{input}
+	other.selectFunc = nil
+
+	err := s.Find("input").Location().Tolerance(1).AlignedWith(other, Left).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = s.Find("input").Location().AlignedWith(other, Top).End()
+	if err == nil || !strings.Contains(err.Error(), "top edge at 100px is not aligned with 'label' at 140px") {
+		t.Fatalf("Expected the elements not to be aligned, got %v", err)
+	}
+}
+
+func TestCropPNG(t *testing.T) {
+	var buff bytes.Buffer
+	err := png.Encode(&buff, image.NewRGBA(image.Rect(0, 0, 100, 50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cropped, err := cropPNG(buff.Bytes(), image.Rect(90, 40, 120, 60))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	img, err := png.Decode(bytes.NewReader(cropped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 10 || img.Bounds().Dy() != 10 {
+		t.Fatalf("Expected the crop to be clipped to the screenshot, got %s", img.Bounds())
+	}
+}
+
+// failingWriter is an io.Writer which always fails
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestScreenshotTo(t *testing.T) {
+	d := &fakeDriver{screenshot: []byte("png")}
+
+	var buff []byte
+	err := Start(d).ScreenshotBytes(&buff).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(buff) != "png" {
+		t.Fatalf("Expected the screenshot's bytes, got %q", buff)
+	}
+
+	err = Start(d).ScreenshotTo(failingWriter{}).End()
+	sErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected a sequence error, got %v", err)
+	}
+	if sErr.Stage != "Screenshot Write" || sErr.Caller == "" {
+		t.Fatalf("Expected a Screenshot Write error with a caller, got %s", sErr)
+	}
+}
+
+// scrollingDriver is a fakeDriver for a page 250 pixels high in a 100 pixel viewport, whose screenshots are filled
+// with a gray level of the current scroll position
+type scrollingDriver struct {
+	*fakeDriver
+	top float64
+}
+
+func (d *scrollingDriver) ExecuteScript(script string, args []interface{}) (interface{}, error) {
+	if script == pageSizeScript {
+		return []interface{}{250.0, 100.0, 0.0, 0.0, 1.0}, nil
+	}
+	d.top = math.Min(args[1].(float64), 150)
+	return d.top, nil
+}
+
+func (d *scrollingDriver) Screenshot() ([]byte, error) {
+	img := image.NewGray(image.Rect(0, 0, 10, 100))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(d.top)
+	}
+	var buff bytes.Buffer
+	err := png.Encode(&buff, img)
+	return buff.Bytes(), err
+}
+
+func TestFullPageScreenshot(t *testing.T) {
+	d := &scrollingDriver{fakeDriver: &fakeDriver{}}
+	s := Start(d).FullPageHeaderCrop(20)
+
+	buff, err := s.fullPageScreenshot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	img, err := png.Decode(bytes.NewReader(buff))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dy() != 250 {
+		t.Fatalf("Expected the screenshot to be the height of the page, got %d", img.Bounds().Dy())
+	}
+	for y, top := range map[int]uint8{50: 0, 150: 80, 240: 150} {
+		r, _, _, _ := img.At(5, y).RGBA()
+		if uint8(r>>8) != top {
+			t.Fatalf("Expected row %d to come from the screenshot scrolled to %d, got %d", y, top, r>>8)
+		}
+	}
+	if d.top != 0 {
+		t.Fatalf("Expected the scroll position to be restored, got %v", d.top)
+	}
+}
+
+func TestMatchesBaseline(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 10, 10))
+	var buff bytes.Buffer
+	if err := png.Encode(&buff, img); err != nil {
+		t.Fatal(err)
+	}
+	d := &fakeDriver{screenshot: buff.Bytes()}
+	baseline := filepath.Join(t.TempDir(), "home.png")
+
+	err := Start(d).MatchesBaseline(baseline, BaselineRecord()).End()
+	if err != nil {
+		t.Fatalf("Unexpected error recording the baseline: %s", err)
+	}
+	err = Start(d).MatchesBaseline(baseline).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	img.Pix[0] = 255
+	buff.Reset()
+	if err = png.Encode(&buff, img); err != nil {
+		t.Fatal(err)
+	}
+	d.screenshot = buff.Bytes()
+
+	err = Start(d).MatchesBaseline(baseline, BaselineThreshold(0.02)).End()
+	if err != nil {
+		t.Fatalf("Expected 1%% of pixels differing to pass, got %s", err)
+	}
+	err = Start(d).MatchesBaseline(baseline).End()
+	if err == nil || !strings.Contains(err.Error(), "1.00% of pixels differ") {
+		t.Fatalf("Expected the baseline not to match, got %v", err)
+	}
+	for _, name := range []string{"home.actual.png", "home.diff.png"} {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(baseline), name)); err != nil {
+			t.Fatalf("Expected %s to be written: %s", name, err)
+		}
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	buff := &bytes.Buffer{}
+	Start(&fakeDriver{}).SetOutput(buff).Debug()
+	if !strings.Contains(buff.String(), "Fake - (http://localhost/fake)") {
+		t.Fatalf("Expected Debug to write to the output, got '%s'", buff.String())
+	}
+
+	tb := &fakeTB{}
+	Start(&fakeDriver{}).Verbose(nil).
+		OnError(func(err Error, s *Sequence) {
+			s.DebugLog()
+		}).
+		Test("Failing", func(d selenium.WebDriver) error {
+			return errors.New("failed")
+		}).Ok(tb)
+	if len(tb.logs) != 2 || !strings.HasPrefix(tb.logs[0], "FAIL  Failing") ||
+		!strings.Contains(tb.logs[1], "browser log not available") {
+		t.Fatalf("Expected output while ending to be logged to the test, got %q", tb.logs)
+	}
+}
+
+func TestDebugOptions(t *testing.T) {
+	buff := &bytes.Buffer{}
+	Start(&fakeDriver{}).SetOutput(buff).Debug(DebugNoSource())
+	if strings.Contains(buff.String(), "<html>") {
+		t.Fatalf("Expected the source to be left out, got '%s'", buff.String())
+	}
+
+	indented := indentHTML(`<div class="app"><p>Hello<br>world</p><img src="a.png"/></div>`)
+	expected := "<div class=\"app\">\n  <p>\n    Hello\n    <br>\n    world\n  </p>\n  <img src=\"a.png\"/>\n</div>"
+	if indented != expected {
+		t.Fatalf("Expected indented HTML:\n%s\ngot:\n%s", expected, indented)
+	}
+
+	src, err := Start(&fakeDriver{}).debugSource(&debugOptions{maxSource: 8})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if src != "<html>\n<\n... (6 more characters)" {
+		t.Fatalf("Expected the source to be truncated, got %q", src)
+	}
+}
+
+func TestSaveSource(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pages", "home.html")
+	err := Start(&fakeDriver{}).SaveSource(filename).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src) != "<html></html>" {
+		t.Fatalf("Expected the page's source to be saved, got '%s'", src)
+	}
+
+	err = Start(&fakeDriver{}).SaveSourceTo(failingWriter{}).End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Save Source Write" || sErr.Caller == "" {
+		t.Fatalf("Expected a Save Source Write error with a caller, got %v", err)
+	}
+}
+
+func TestFilenameTemplate(t *testing.T) {
+	s := Start(&fakeDriver{}).Step("Log In")
+	s.testName = "TestLogin/admin"
+
+	name, err := s.filename("shots/{test}-{step}-{seq}-{seq}.png")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if name != "shots/TestLogin-admin-Log-In-1-1.png" {
+		t.Fatalf("Unexpected file name '%s'", name)
+	}
+	name, _ = s.filename("{seq}.png")
+	if name != "2.png" {
+		t.Fatalf("Expected the counter to go up, got '%s'", name)
+	}
+
+	err = Start(&fakeDriver{}).SaveSource(filepath.Join(t.TempDir(), "{date}.html")).End()
+	if err == nil || !strings.Contains(err.Error(), "Unknown token {date}") {
+		t.Fatalf("Expected an unknown token error, got %v", err)
+	}
+}
+
+func TestLoadTimeUnder(t *testing.T) {
+	metric := ""
+	var result interface{} = 3250.5
+	d := &fakeDriver{
+		script: func(script string, args []interface{}) (interface{}, error) {
+			metric = args[0].(string)
+			return result, nil
+		},
+	}
+
+	err := Start(d).LoadTimeUnder(3 * time.Second).End()
+	if err == nil || !strings.Contains(err.Error(), "domContentLoaded time was 3.2505s which is over the budget of 3s") {
+		t.Fatalf("Expected the measured time and metric in the error, got %v", err)
+	}
+
+	err = Start(d).LoadTimeUnder(5*time.Second, DOMInteractive).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if metric != "domInteractive" {
+		t.Fatalf("Expected the domInteractive metric to be measured, got '%s'", metric)
+	}
+
+	result = nil
+	err = Start(d).LoadTimeUnder(time.Second, FirstContentfulPaint).End()
+	if err == nil || !strings.Contains(err.Error(), "has not recorded a firstContentfulPaint time") {
+		t.Fatalf("Expected an unavailable metric to fail, got %v", err)
+	}
+}
+
+// pollingDriver is a fakeDriver whose Wait polls the condition a set number of times before timing out
+type pollingDriver struct {
+	fakeDriver
+	polls int
+}
+
+func (d *pollingDriver) WaitWithTimeoutAndInterval(condition selenium.Condition, timeout,
+	interval time.Duration) error {
+	for i := 0; i < d.polls; i++ {
+		ok, err := condition(d)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+	return errors.New("timeout")
+}
+
+func TestWaitForNetworkIdle(t *testing.T) {
+	count := 3.0
+	d := &pollingDriver{
+		fakeDriver: fakeDriver{
+			script: func(script string, args []interface{}) (interface{}, error) {
+				if count < 6 {
+					count++
+				}
+				return []interface{}{count, []interface{}{"http://localhost/api/orders"}}, nil
+			},
+		},
+		polls: 10,
+	}
+
+	s := Start(d)
+	s.EventualPoll = 10 * time.Millisecond
+	err := s.WaitForNetworkIdle(20 * time.Millisecond).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	count = 0
+	d.polls = 3
+	err = s.WaitForNetworkIdle(time.Second).End()
+	if err == nil || !strings.Contains(err.Error(), "2 resources were loaded while waiting, the most recent "+
+		"were: \n\thttp://localhost/api/orders") {
+		t.Fatalf("Expected the arriving resources in the error, got %v", err)
+	}
+}
+
+func TestFilterByText(t *testing.T) {
+	d := &pollingDriver{
+		fakeDriver: fakeDriver{elems: textElements("Order 1", "Refund 2", "Refund 3")},
+		polls:      3,
+	}
+
+	err := Start(d).Find(".row").FilterByTextContains("Refund").Count(2).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	e := Start(d).Find(".row").FilterByText("Refund 4").Count(1)
+	d.elems = textElements("Refund 2", "Refund 4")
+	err = e.Eventually().End()
+	if err != nil {
+		t.Fatalf("Expected Eventually to re-select and re-filter, got %s", err)
+	}
+
+	d.elems = []selenium.WebElement{
+		&fakeElement{attrs: map[string]string{"data-status": "open"}},
+		&fakeElement{attrs: map[string]string{"data-status": "closed"}},
+	}
+	err = Start(d).Find(".row").FilterByAttribute("data-status", "closed").Count(1).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestFilterDiagnostics(t *testing.T) {
+	d := &fakeDriver{elems: textElements("Order 1", "Order 2", "Order 3", "Order 4")}
+
+	err := Start(d).Find(".row").FilterByTextContains("Refund").Text().Equals("Refund 1").End()
+	if !errors.Is(err, ErrNoElements) {
+		t.Fatalf("Expected a no elements error, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "the selector matched 4 elements but 0 passed the filter Text Contains 'Refund'") {
+		t.Fatalf("Expected the filter in the error, got %s", msg)
+	}
+	if !strings.Contains(msg, "Element 3: ") || !strings.Contains(msg, "Got 'Order 3'") ||
+		strings.Contains(msg, "Order 4'") || !strings.Contains(msg, "... and 1 more") {
+		t.Fatalf("Expected the first few filter errors in the error, got %s", msg)
+	}
+
+	err = Start(d).Find(".row").Filter(func(we *Elements) error {
+		return errors.New("rejected")
+	}).Count(1).End()
+	if err == nil || !strings.Contains(err.Error(), "got 0: the selector matched 4 elements but 0 passed the filter") {
+		t.Fatalf("Expected the filter in the count error, got %v", err)
+	}
+}
+
+func TestFindChildren(t *testing.T) {
+	stale := &fakeElement{findErr: errors.New("stale element reference")}
+	fresh := &fakeElement{children: map[string][]selenium.WebElement{".price": textElements("$5")}}
+	d := &pollingDriver{
+		fakeDriver: fakeDriver{elems: []selenium.WebElement{stale}},
+		polls:      3,
+	}
+
+	cart := Start(d).Find(".cart").All()
+	children := cart.FindChildren(".price")
+	if children.selector != ".price" || children.all {
+		t.Fatalf("Expected the children's Elements without the parent's quantifier, got selector '%s' all %t",
+			children.selector, children.all)
+	}
+	sErr, ok := children.End().(*Error)
+	if !ok || sErr.Stage != "Find Children" || sErr.Selector != ".price" || sErr.Caller == "" {
+		t.Fatalf("Expected a Find Children error for the child selector, got %v", children.End())
+	}
+
+	d.elems = []selenium.WebElement{fresh}
+	err := children.Eventually().Text().Equals("$5").End()
+	if err != nil {
+		t.Fatalf("Expected Eventually to re-select the parents, got %s", err)
+	}
+	if len(cart.elems) != 1 || cart.elems[0] != stale {
+		t.Fatalf("Expected re-selecting the children to leave the parent's selection alone")
+	}
+
+	err = Start(d).Find(".cart").FilterByText("none").FindChildren(".price").Count(0).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	failing := Start(d).Test("Failing", func(d selenium.WebDriver) error {
+		return errors.New("failed")
+	})
+	err = failing.Find(".cart").FindChildren(".price").Eventually().Parent().EventuallyFor(time.Millisecond,
+		time.Millisecond).Consistently(time.Millisecond).End()
+	sErr, ok = err.(*Error)
+	if !ok || sErr.Stage != "Failing" {
+		t.Fatalf("Expected the elements selected after the failure to keep its error, got %v", err)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	items := &fakeElement{children: map[string][]selenium.WebElement{".price": textElements("$5")}}
+	panel := &fakeElement{children: map[string][]selenium.WebElement{
+		".items": {items},
+		".price": textElements("$5", "$7"),
+	}}
+	d := &fakeDriver{elems: []selenium.WebElement{panel}}
+
+	err := Start(d).Within("#checkout", func(s *Sequence) *Sequence {
+		return s.Find(".price").Any().Text().Equals("$7").And().
+			Within(".items", func(s *Sequence) *Sequence {
+				return s.Find(".price").Text().Equals("$5").And()
+			})
+	}).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = Start(d).Within("#checkout", func(s *Sequence) *Sequence {
+		return s.Within(".items", func(s *Sequence) *Sequence {
+			return s.Find(".total").Text().Equals("$5").And()
+		})
+	}).Find(".total").End()
+	sErr, ok := err.(*Error)
+	if !ok || !strings.Contains(sErr.Error(), "No elements exist for the selector '.total' (within '#checkout .items')") {
+		t.Fatalf("Expected the containers in the error, got %v", err)
+	}
+	if !strings.HasPrefix(sErr.Caller, "sequence_test.go:") {
+		t.Fatalf("Expected the error to point at the test, got %s", sErr.Caller)
+	}
+}
+
+func TestDeadlineTitle(t *testing.T) {
+	err := Start(&fakeDriver{}).Deadline(time.Millisecond).Wait(5 * time.Millisecond).
+		Title().Equals("Fake").End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Sequence Deadline Exceeded" || !strings.Contains(sErr.Error(), "before running Title Equals") {
+		t.Fatalf("Expected the title step to fail with the deadline exceeded, got %v", err)
+	}
+}
+
+func TestContextCancelledWait(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := &pollingDriver{polls: 5}
+
+	err := StartWithContext(ctx, d).WaitForURL(func(u *url.URL) bool {
+		cancel()
+		return false
+	}).End()
+	if err == nil || !strings.Contains(err.Error(), "context was cancelled while waiting: context canceled") {
+		t.Fatalf("Expected the wait to stop when the context was cancelled, got %v", err)
+	}
+
+	err = StartWithContext(ctx, d).Title().Equals("Fake").End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Context Cancelled" {
+		t.Fatalf("Expected the title step to fail with the context cancelled, got %v", err)
+	}
+}
+
+func TestEmptyCaller(t *testing.T) {
+	d := &fakeDriver{elems: textElements("Refund")}
+	_, _, line, _ := runtime.Caller(0)
+	err := Start(d).Find(".status").Text().Empty().End()
+
+	sErr, ok := err.(*Error)
+	if !ok || !strings.Contains(sErr.Error(), "is not empty. Got 'Refund'") {
+		t.Fatalf("Expected a not empty error, got %v", err)
+	}
+	if !strings.HasSuffix(sErr.Caller, fmt.Sprintf("sequence_test.go:%d", line+1)) {
+		t.Fatalf("Expected the caller to be the line calling Empty, got %s", sErr.Caller)
+	}
+}
+
+func TestPushFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "avatar.png")
+	err := os.WriteFile(file, []byte("png"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/session/fake/se/file" {
+			fmt.Fprint(w, `{"value": "/tmp/upload/avatar.png"}`)
+			return
+		}
+		if r.URL.Path == "/session/fake/file" {
+			http.NotFound(w, r)
+			return
+		}
+		<-hang
+	}))
+	defer server.Close()
+	defer close(hang)
+
+	s := Start(&fakeDriver{})
+	s.RemoteURL = server.URL
+	path, err := s.pushFile(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if path != "/tmp/upload/avatar.png" {
+		t.Fatalf("Expected the remote path, got '%s'", path)
+	}
+
+	s.RemoteURL = server.URL + "/hung"
+	s.EventualTimeout = 50 * time.Millisecond
+	_, err = s.pushFile(file)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a hung server to time out, got %v", err)
+	}
+}
+
+func TestScriptAsyncTimeout(t *testing.T) {
+	d := &fakeDriver{
+		script: func(script string, args []interface{}) (interface{}, error) {
+			return "done", nil
+		},
+	}
+
+	err := Start(d).AsyncScriptTimeout(time.Minute).
+		ScriptAsync("arguments[0]('done');", time.Second).Equals("done").End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []time.Duration{time.Minute, time.Second, time.Minute}
+	if !reflect.DeepEqual(d.scriptTimeouts, expected) {
+		t.Fatalf("Expected the script timeout to be restored, got %v", d.scriptTimeouts)
+	}
+}
+
+// countingDriver is a fakeDriver which counts how many times the current url is requested
+type countingDriver struct {
+	fakeDriver
+	urls int
+}
+
+func (d *countingDriver) CurrentURL() (string, error) {
+	d.urls++
+	return d.fakeDriver.CurrentURL()
+}
+
+func TestFilterSkipsErrorDetails(t *testing.T) {
+	d := &countingDriver{fakeDriver: fakeDriver{elems: textElements("Order 1", "Order 2", "Refund 3")}}
+
+	err := Start(d).Find(".row").FilterByTextContains("Refund").Count(1).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if d.urls != 0 {
+		t.Fatalf("Expected no error details to be collected for filtered out elements, got %d url requests", d.urls)
+	}
+}
+
+func TestURLRegexpRaw(t *testing.T) {
+	d := &fakeDriver{url: "HTTP://localhost/files/my report"}
+
+	err := Start(d).URL().Regexp(regexp.MustCompile(`^HTTP://localhost/files/my report$`)).End()
+	if err != nil {
+		t.Fatalf("Expected the url to be matched as reported by the browser, got %s", err)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	d := &pollingDriver{
+		fakeDriver: fakeDriver{elems: textElements("a", "b")},
+		polls:      3,
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	e := Start(d).Find("li").Slice(1, 3)
+	sErr, ok := e.End().(*Error)
+	if !ok || sErr.Selector != "li" || !strings.HasSuffix(sErr.Caller, fmt.Sprintf("sequence_test.go:%d", line+1)) {
+		t.Fatalf("Expected a Slice error with the selector and caller, got %v", e.End())
+	}
+
+	e = Start(d).Find("li").Slice(1, 3).Any().Text().Equals("c")
+	d.elems = textElements("a", "b", "c", "d")
+	err := e.Eventually().End()
+	if err != nil {
+		t.Fatalf("Expected Eventually to re-select and re-slice, got %s", err)
+	}
+}
+
+// frameDriver is a fakeDriver which can switch into frames, failing to switch into the frame in fail
+type frameDriver struct {
+	fakeDriver
+	fail selenium.WebElement
+}
+
+func (d *frameDriver) SwitchFrame(frame interface{}) error {
+	if frame != nil && frame == d.fail {
+		return errors.New("no such frame")
+	}
+	return nil
+}
+
+func (d *frameDriver) Get(url string) error {
+	return nil
+}
+
+func TestFrameStack(t *testing.T) {
+	outer := &fakeElement{}
+	inner := &fakeElement{}
+	d := &frameDriver{}
+	d.elems = []selenium.WebElement{outer}
+
+	s := Start(d).SwitchToFrame("#outer")
+	d.elems = []selenium.WebElement{inner}
+	s.SwitchToFrame("#inner")
+	d.fail = outer
+	err := s.SwitchToParentFrame().End()
+	if err == nil || len(s.frames) != 2 {
+		t.Fatalf("Expected the frames to be unchanged after failing to switch, got %v with %d frames", err,
+			len(s.frames))
+	}
+
+	d.fail = nil
+	s = Start(d).SwitchToFrame("#inner").Get("http://localhost/next")
+	if s.End() != nil || len(s.frames) != 0 {
+		t.Fatalf("Expected navigating to leave all frames, got %v with %d frames", s.End(), len(s.frames))
+	}
+}
+
+func TestCookieHTTPOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/session/fake/cookie" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"name": "session", "httpOnly": true}, {"name": "theme", "httpOnly": false}]}`)
+	}))
+	defer server.Close()
+
+	d := &fakeDriver{cookies: []selenium.Cookie{{Name: "session"}, {Name: "theme"}}}
+	s := Start(d)
+	s.RemoteURL = server.URL
+	err := s.Cookie("session").HTTPOnly().End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	s = Start(d)
+	s.RemoteURL = server.URL
+	err = s.Cookie("theme").HTTPOnly().End()
+	if err == nil || !strings.Contains(err.Error(), "The cookie 'theme' is not http only") {
+		t.Fatalf("Expected a cookie without the flag to fail, got %v", err)
+	}
+
+	err = Start(d).Cookie("session").HTTPOnly().End()
+	if err == nil || !strings.Contains(err.Error(), "needs the sequence's RemoteURL to be set") {
+		t.Fatalf("Expected checking the flag without a RemoteURL to fail, got %v", err)
+	}
+}
+
+// windowDriver is a fakeDriver with windows, whose titles are looked up by handle.  Reading the title of the window
+// in broken fails
+type windowDriver struct {
+	fakeDriver
+	titles  map[string]string
+	handles []string
+	current string
+	broken  string
+}
+
+func (d *windowDriver) CurrentWindowHandle() (string, error) {
+	return d.current, nil
+}
+
+func (d *windowDriver) WindowHandles() ([]string, error) {
+	return d.handles, nil
+}
+
+func (d *windowDriver) SwitchWindow(name string) error {
+	d.current = name
+	return nil
+}
+
+func (d *windowDriver) Title() (string, error) {
+	if d.current == d.broken {
+		return "", errors.New("no such window")
+	}
+	return d.titles[d.current], nil
+}
+
+func TestSwitchToWindowRestores(t *testing.T) {
+	d := &windowDriver{
+		titles:  map[string]string{"main": "Orders", "popup": "Help"},
+		handles: []string{"main", "popup"},
+		current: "main",
+	}
+
+	s := Start(d)
+	s.frames = []selenium.WebElement{&fakeElement{}}
+	err := s.SwitchToWindow(WindowTitle("Checkout")).End()
+	if err == nil || !strings.Contains(err.Error(), "No window matched") {
+		t.Fatalf("Expected no window to match, got %v", err)
+	}
+	if d.current != "main" || len(s.frames) != 0 {
+		t.Fatalf("Expected to be back in the main window out of any frames, got '%s' with %d frames", d.current,
+			len(s.frames))
+	}
+
+	d.broken = "popup"
+	err = Start(d).SwitchToWindow(WindowTitle("Help")).End()
+	if err == nil || !strings.Contains(err.Error(), "no such window") {
+		t.Fatalf("Expected the title error, got %v", err)
+	}
+	if d.current != "main" {
+		t.Fatalf("Expected to be switched back to the main window, got '%s'", d.current)
+	}
+}
+
+// recordingReporter records the steps reported as finished
+type recordingReporter struct {
+	finished []StepEvent
+}
+
+func (r *recordingReporter) StepStarted(step StepEvent) {}
+
+func (r *recordingReporter) StepFinished(step StepEvent, err error) {
+	r.finished = append(r.finished, step)
+}
+
+func (r *recordingReporter) SequenceFinished(err error) {}
+
+func TestReporterScreenshot(t *testing.T) {
+	dir := t.TempDir()
+	r := &recordingReporter{}
+	err := Start(&fakeDriver{screenshot: []byte("png")}).ScreenshotOnError(dir).WithReporter(r).
+		Test("Failing", func(d selenium.WebDriver) error {
+			return errors.New("failed")
+		}).End()
+	if err == nil {
+		t.Fatalf("Expected the sequence to fail")
+	}
+	if len(r.finished) != 1 {
+		t.Fatalf("Expected 1 finished step, got %d", len(r.finished))
+	}
+	screenshot := r.finished[0].Screenshot
+	if screenshot == "" || screenshot != err.(*Error).Screenshot {
+		t.Fatalf("Expected the finished step to have the error's screenshot, got '%s'", screenshot)
+	}
+	if _, sErr := os.Stat(screenshot); sErr != nil {
+		t.Fatalf("Expected the screenshot to have been written: %s", sErr)
+	}
+}
+
+// viewportDriver is a fakeDriver whose screenshots are of its current window size
+type viewportDriver struct {
+	fakeDriver
+	width, height int
+}
+
+func (d *viewportDriver) CurrentWindowHandle() (string, error) {
+	return "main", nil
+}
+
+func (d *viewportDriver) ResizeWindow(name string, width, height int) error {
+	d.width, d.height = width, height
+	return nil
+}
+
+func (d *viewportDriver) ExecuteScript(script string, args []interface{}) (interface{}, error) {
+	return []interface{}{float64(d.width), float64(d.height), float64(d.width), float64(d.height)}, nil
+}
+
+func (d *viewportDriver) Screenshot() ([]byte, error) {
+	return []byte(fmt.Sprintf("%dx%d", d.width, d.height)), nil
+}
+
+func TestScreenshotOnErrorWithViewport(t *testing.T) {
+	d := &viewportDriver{width: 1024, height: 768}
+	err := Start(d).ScreenshotOnError(t.TempDir()).
+		WithViewport(320, 480, func(s *Sequence) *Sequence {
+			return s.Test("Mobile Menu", func(d selenium.WebDriver) error {
+				return errors.New("menu hidden")
+			})
+		}).End()
+	if err == nil {
+		t.Fatalf("Expected the sequence to fail")
+	}
+	if d.width != 1024 || d.height != 768 {
+		t.Fatalf("Expected the viewport to be restored, got %dx%d", d.width, d.height)
+	}
+	buff, rErr := os.ReadFile(err.(*Error).Screenshot)
+	if rErr != nil {
+		t.Fatalf("Error reading the screenshot: %s", rErr)
+	}
+	if string(buff) != "320x480" {
+		t.Fatalf("Expected the screenshot to be taken at the viewport the step failed at, got %s", buff)
+	}
+}
+
+// offlineDriver is a fakeDriver for a server which can't be reached
+type offlineDriver struct {
+	fakeDriver
+	gets int
+}
+
+func (d *offlineDriver) Get(url string) error {
+	d.gets++
+	return errors.New("connection refused")
+}
+
+func TestGetWithRetry(t *testing.T) {
+	d := &offlineDriver{}
+	err := Start(d).GetWithRetry("http://localhost", 0, time.Millisecond).End()
+	if err == nil || !strings.Contains(err.Error(), "at least 1 attempt") || d.gets != 0 {
+		t.Fatalf("Expected 0 attempts to be rejected, got %v", err)
+	}
+
+	err = Start(d).GetWithRetry("http://localhost", 3, time.Millisecond).End()
+	if err == nil || !strings.Contains(err.Error(), "failed after 3 attempts") || d.gets != 3 {
+		t.Fatalf("Expected 3 failed attempts, got %d: %v", d.gets, err)
+	}
+
+	d.gets = 0
+	start := time.Now()
+	err = Start(d).Deadline(10*time.Millisecond).GetWithRetry("http://localhost", 3, time.Hour).End()
+	if err == nil || !strings.Contains(err.Error(), "deadline was reached") || d.gets != 1 {
+		t.Fatalf("Expected the deadline to stop the retries, got %d attempts: %v", d.gets, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("Expected the delay to be cut short by the deadline, took %s", time.Since(start))
+	}
+
+	d.gets = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = StartWithContext(ctx, d).GetWithRetry("http://localhost", 3, time.Hour).End()
+	if err == nil || !strings.Contains(err.Error(), "cancelled while waiting") || d.gets != 1 {
+		t.Fatalf("Expected cancelling the context to stop the retries, got %d attempts: %v", d.gets, err)
+	}
+}
+
+// staleDriver is a fakeDriver whose elements can only be found a limited number of times
+type staleDriver struct {
+	fakeDriver
+	finds int
+}
+
+func (d *staleDriver) FindElements(by, value string) ([]selenium.WebElement, error) {
+	if d.finds == 0 {
+		return nil, errors.New("stale element reference")
+	}
+	d.finds--
+	return d.elems, nil
+}
+
+func TestConsistently(t *testing.T) {
+	d := &staleDriver{fakeDriver: fakeDriver{elems: textElements("Saved")}, finds: 2}
+	s := Start(d)
+	s.EventualPoll = time.Millisecond
+	err := s.Find(".status").Text().Equals("Saved").Consistently(time.Second).End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Consistently Elements" || sErr.Selector != ".status" {
+		t.Fatalf("Expected the re-select error to have the selector, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s = StartWithContext(ctx, &fakeDriver{})
+	s.EventualPoll = time.Hour
+	start := time.Now()
+	err = s.Title().Equals("Fake").Consistently(time.Hour).End()
+	sErr, ok = err.(*Error)
+	if !ok || sErr.Stage != "Context Cancelled" || time.Since(start) > time.Second {
+		t.Fatalf("Expected cancelling the context to stop Consistently, got %v after %s", err, time.Since(start))
+	}
+
+	s = Start(&fakeDriver{elems: textElements("Saved")}).Deadline(10 * time.Millisecond)
+	s.EventualPoll = time.Hour
+	start = time.Now()
+	err = s.Find(".status").Text().Equals("Saved").Consistently(time.Hour).End()
+	sErr, ok = err.(*Error)
+	if !ok || sErr.Stage != "Sequence Deadline Exceeded" || time.Since(start) > time.Second {
+		t.Fatalf("Expected the deadline to stop Consistently, got %v after %s", err, time.Since(start))
+	}
+}
+
+func TestDragToElements(t *testing.T) {
+	stale := &fakeElement{}
+	fresh := &fakeElement{}
+	board := &fakeElement{children: map[string][]selenium.WebElement{".slot": {stale}}}
+	var dropped interface{}
+	d := &fakeDriver{
+		elems: []selenium.WebElement{board},
+		script: func(script string, args []interface{}) (interface{}, error) {
+			dropped = args[1]
+			return nil, nil
+		},
+	}
+	s := Start(d)
+	s.DragMethod = DragScript
+	target := s.Find("#board").FindChildren(".slot")
+
+	board.children[".slot"] = []selenium.WebElement{fresh}
+	err := s.Find(".card").DragToElements(target).End()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if dropped != fresh {
+		t.Fatalf("Expected the drag target to be re-selected")
+	}
+
+	board.children[".slot"] = nil
+	err = s.Find(".card").DragToElements(target).End()
+	sErr, ok := err.(*Error)
+	if !ok || sErr.Stage != "Drag To Elements Test" {
+		t.Fatalf("Expected a Drag To Elements error, got %v", err)
+	}
+}
+
+// formElement is a fakeElement which calls its submit function when submitted
+type formElement struct {
+	fakeElement
+	submit func()
+}
+
+func (e *formElement) Submit() error {
+	e.submit()
+	return nil
+}
+
+func TestSubmitAndWaitPostBack(t *testing.T) {
+	// the page's document is marked until the form replaces it
+	marked := false
+	form := &formElement{}
+	d := &pollingDriver{
+		fakeDriver: fakeDriver{
+			elems: []selenium.WebElement{form},
+			script: func(script string, args []interface{}) (interface{}, error) {
+				if script == navigationMarkScript {
+					marked = true
+					return nil, nil
+				}
+				return !marked, nil
+			},
+		},
+		polls: 3,
+	}
+
+	form.submit = func() {}
+	err := Start(d).Find("form").SubmitAndWait().End()
+	if err == nil || !strings.Contains(err.Error(), "never navigated away from http://localhost/fake") {
+		t.Fatalf("Expected the unchanged page to time out, got %v", err)
+	}
+
+	form.submit = func() {
+		marked = false
+	}
+	err = Start(d).Find("form").SubmitAndWait().End()
+	if err != nil {
+		t.Fatalf("Expected the post back to the same url to be seen as a navigation, got %s", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// excerptLength is how many characters either side of a match are shown in page source failure messages
+const excerptLength = 60
+
+// SourceMatch is for testing the page's source.  Failure messages only include a short excerpt of the source, as
+// the full page source can be very large
+type SourceMatch struct {
+	source string
+	s      *Sequence
+}
+
+// Source tests against the current page's source
+func (s *Sequence) Source() *SourceMatch {
+	return &SourceMatch{
+		s: s,
+	}
+}
+
+func (m *SourceMatch) test(testName string, fn func() error) *Sequence {
+	m.s.last = func() *Sequence {
+		if m.s.failed() {
+			return m.s
+		}
+		if m.s.stopped("Source "+testName, "", 2) {
+			return m.s
+		}
+		src, err := m.s.driver.PageSource()
+		if err == nil {
+			m.source = src
+			err = fn()
+		}
+		if err != nil {
+			m.s.err = &Error{
+				Stage:  "Source " + testName,
+				Err:    err,
+				Caller: m.s.caller(2),
+			}
+		}
+		return m.s
+	}
+	return m.s.last()
+}
+
+// Contains tests if the page's source contains the passed in value
+func (m *SourceMatch) Contains(match string) *Sequence {
+	return m.test("Contains", func() error {
+		if strings.Contains(m.source, match) {
+			return nil
+		}
+		// find the longest leading portion of the match that is in the source, to show where it diverges
+		for i := len(match) - 1; i > 0; i-- {
+			index := strings.Index(m.source, match[:i])
+			if index != -1 {
+				return fmt.Errorf("The page's source (%d characters) does not contain '%s'. Nearest partial "+
+					"match: '%s'", len(m.source), match, excerpt(m.source, index, i))
+			}
+		}
+		return fmt.Errorf("The page's source (%d characters) does not contain '%s'", len(m.source), match)
+	})
+}
+
+// NotContains tests if the page's source does not contain the passed in value
+func (m *SourceMatch) NotContains(match string) *Sequence {
+	return m.test("Not Contains", func() error {
+		index := strings.Index(m.source, match)
+		if index != -1 {
+			return fmt.Errorf("The page's source contains '%s': '%s'", match, excerpt(m.source, index, len(match)))
+		}
+		return nil
+	})
+}
+
+// Regexp tests if the page's source matches the regular expression
+func (m *SourceMatch) Regexp(exp *regexp.Regexp) *Sequence {
+	return m.test("Matches RegExp", func() error {
+		if !exp.MatchString(m.source) {
+			return fmt.Errorf("The page's source (%d characters) does not match the regular expression '%s'",
+				len(m.source), exp)
+		}
+		return nil
+	})
+}
+
+// excerpt returns the portion of src around the match at index with the passed in length
+func excerpt(src string, index, length int) string {
+	start := index - excerptLength
+	prefix := "..."
+	if start <= 0 {
+		start = 0
+		prefix = ""
+	}
+	end := index + length + excerptLength
+	suffix := "..."
+	if end >= len(src) {
+		end = len(src)
+		suffix = ""
+	}
+	return prefix + src[start:end] + suffix
+}
+
+// SaveSource writes the current page's source to the file, creating its directory if needed, for looking into
+// failures after the test has run.  The file name can contain tokens such as {test} and {time}, see Screenshot
+func (s *Sequence) SaveSource(filename string) *Sequence {
+	return s.saveSource("Save Source Writing File", func(src []byte) error {
+		name, err := s.filename(filename)
+		if err != nil {
+			return err
+		}
+		err = os.MkdirAll(filepath.Dir(name), 0755)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(name, src, 0644)
+	})
+}
+
+// SaveSourceTo writes the current page's source to w
+func (s *Sequence) SaveSourceTo(w io.Writer) *Sequence {
+	return s.saveSource("Save Source Write", func(src []byte) error {
+		_, err := w.Write(src)
+		return err
+	})
+}
+
+// saveSource passes the page's source, with any redacted values removed, to fn, failing with the stage if fn returns
+// an error
+func (s *Sequence) saveSource(stage string, fn func(src []byte) error) *Sequence {
+	s.last = func() *Sequence {
+		if s.failed() {
+			return s
+		}
+		if s.stopped("Save Source", "", 2) {
+			return s
+		}
+		src, err := s.driver.PageSource()
+		if err != nil {
+			s.err = &Error{
+				Stage:  "Save Source",
+				Err:    err,
+				Caller: s.caller(2),
+			}
+			return s
+		}
+		err = fn([]byte(redact(src, s.redacted)))
+		if err != nil {
+			s.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: s.caller(2),
+			}
+		}
+		return s
+	}
+	return s.last()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+// LocalStorage tests against the value of the key in the page's localStorage.  The test fails if the key
+// isn't present
+func (s *Sequence) LocalStorage(key string) *ValueMatch {
+	return s.storage("localStorage", key)
+}
+
+// SessionStorage tests against the value of the key in the page's sessionStorage.  The test fails if the key
+// isn't present
+func (s *Sequence) SessionStorage(key string) *ValueMatch {
+	return s.storage("sessionStorage", key)
+}
+
+func (s *Sequence) storage(storage, key string) *ValueMatch {
+	return &ValueMatch{
+		testName: fmt.Sprintf("%s %s", storage, key),
+		value: func() (string, error) {
+			result, err := s.driver.ExecuteScript(
+				fmt.Sprintf("return window.%s.getItem(arguments[0]);", storage), []interface{}{key})
+			if err != nil {
+				return "", err
+			}
+			if result == nil {
+				return "", fmt.Errorf("The key '%s' is not present in %s", key, storage)
+			}
+			value, ok := result.(string)
+			if !ok {
+				return "", fmt.Errorf("Unexpected %s value for key '%s': %v", storage, key, result)
+			}
+			return value, nil
+		},
+		s: s,
+	}
+}
+
+// SetLocalStorage sets the key to the value in the page's localStorage
+func (s *Sequence) SetLocalStorage(key, value string) *Sequence {
+	return s.test("Set localStorage", func(d selenium.WebDriver) error {
+		_, err := d.ExecuteScript("window.localStorage.setItem(arguments[0], arguments[1]);",
+			[]interface{}{key, value})
+		return err
+	})
+}
+
+// ClearLocalStorage removes all keys from the page's localStorage
+func (s *Sequence) ClearLocalStorage() *Sequence {
+	return s.test("Clear localStorage", func(d selenium.WebDriver) error {
+		_, err := d.ExecuteScript("window.localStorage.clear();", nil)
+		return err
+	})
+}
+
+// SetSessionStorage sets the key to the value in the page's sessionStorage
+func (s *Sequence) SetSessionStorage(key, value string) *Sequence {
+	return s.test("Set sessionStorage", func(d selenium.WebDriver) error {
+		_, err := d.ExecuteScript("window.sessionStorage.setItem(arguments[0], arguments[1]);",
+			[]interface{}{key, value})
+		return err
+	})
+}
+
+// ClearSessionStorage removes all keys from the page's sessionStorage
+func (s *Sequence) ClearSessionStorage() *Sequence {
+	return s.test("Clear sessionStorage", func(d selenium.WebDriver) error {
+		_, err := d.ExecuteScript("window.sessionStorage.clear();", nil)
+		return err
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// Table is for selecting the rows and cells of a selected table element.  Rows are the rows in the table's body,
+// and rows and columns are indexed from 0.  Everything selected from a table is re-selected from the table's
+// selector when retried with Eventually
+type Table struct {
+	e *Elements
+}
+
+// AsTable treats the selected table element as a table
+func (e *Elements) AsTable() *Table {
+	return &Table{e: e}
+}
+
+// Row selects the cells in the row
+func (t *Table) Row(row int) *Elements {
+	return t.e.traverse("Table Row", fmt.Sprintf("%s (row %d)", t.e.selector, row),
+		t.each(func(table selenium.WebElement) ([]selenium.WebElement, error) {
+			return tableRow(table, row)
+		}))
+}
+
+// Cell selects the cell in the row and column
+func (t *Table) Cell(row, col int) *Elements {
+	return t.e.traverse("Table Cell", fmt.Sprintf("%s (row %d column %d)", t.e.selector, row, col),
+		t.each(func(table selenium.WebElement) ([]selenium.WebElement, error) {
+			return tableCell(table, row, col)
+		}))
+}
+
+// CellByHeader selects the cell in the row, and in the column whose header in the table's head has the passed in
+// text
+func (t *Table) CellByHeader(row int, header string) *Elements {
+	return t.e.traverse("Table Cell", fmt.Sprintf("%s (row %d column '%s')", t.e.selector, row, header),
+		t.each(func(table selenium.WebElement) ([]selenium.WebElement, error) {
+			col, err := tableColumn(table, header)
+			if err != nil {
+				return nil, err
+			}
+			return tableCell(table, row, col)
+		}))
+}
+
+// RowCount tests if the table's body has exactly n rows.  The rows are selected for the rest of the chain
+func (t *Table) RowCount(n int) *Elements {
+	rows := t.e.traverse("Table Rows", fmt.Sprintf("%s (rows)", t.e.selector), t.each(tableRows))
+	defer rows.AddCallerSkip(1).AddCallerSkip(-1)
+	return rows.Count(n)
+}
+
+// each returns a traversal which selects from the table with fn, checking that a single table is selected
+func (t *Table) each(fn func(table selenium.WebElement) ([]selenium.WebElement,
+	error)) func([]selenium.WebElement) ([]selenium.WebElement, error) {
+	return func(tables []selenium.WebElement) ([]selenium.WebElement, error) {
+		if len(tables) != 1 {
+			return nil, fmt.Errorf("Selector '%s' matched %d elements, expected a single table", t.e.selector,
+				len(tables))
+		}
+		return fn(tables[0])
+	}
+}
+
+// tableRows returns the rows in the table's body.  Selectors are scoped to the table so the rows of tables nested
+// in its cells aren't included
+func tableRows(table selenium.WebElement) ([]selenium.WebElement, error) {
+	return table.FindElements(selenium.ByCSSSelector, ":scope > tbody > tr")
+}
+
+// tableRow returns the cells in the row of the table's body
+func tableRow(table selenium.WebElement, row int) ([]selenium.WebElement, error) {
+	rows, err := tableRows(table)
+	if err != nil {
+		return nil, err
+	}
+	if row < 0 || row >= len(rows) {
+		return nil, fmt.Errorf("Row %d is out of range, the table has %d rows", row, len(rows))
+	}
+	return rows[row].FindElements(selenium.ByCSSSelector, ":scope > th, :scope > td")
+}
+
+// tableCell returns the cell in the row and column of the table's body
+func tableCell(table selenium.WebElement, row, col int) ([]selenium.WebElement, error) {
+	cells, err := tableRow(table, row)
+	if err != nil {
+		return nil, err
+	}
+	if col < 0 || col >= len(cells) {
+		return nil, fmt.Errorf("Column %d is out of range, row %d has %d cells", col, row, len(cells))
+	}
+	return []selenium.WebElement{cells[col]}, nil
+}
+
+// tableColumn returns the index of the column with the header in the table's head
+func tableColumn(table selenium.WebElement, header string) (int, error) {
+	headers, err := table.FindElements(selenium.ByCSSSelector, ":scope > thead > tr > th")
+	if err != nil {
+		return 0, err
+	}
+	available := make([]string, len(headers))
+	for i := range headers {
+		text, err := headers[i].Text()
+		if err != nil {
+			return 0, err
+		}
+		text = strings.TrimSpace(text)
+		if text == header {
+			return i, nil
+		}
+		available[i] = text
+	}
+	return 0, fmt.Errorf("The table has no column with the header '%s'. Available headers: %q", header, available)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// filenameToken matches the tokens in templated file names, such as {time}
+var filenameToken = regexp.MustCompile(`\{[^{}]*\}`)
+
+// filename expands the tokens in a file name template, see Screenshot for the tokens.  Unknown tokens are an error,
+// rather than being left in the file name
+func (s *Sequence) filename(template string) (string, error) {
+	var err error
+	counted := false
+	name := filenameToken.ReplaceAllStringFunc(template, func(token string) string {
+		switch token {
+		case "{time}":
+			return time.Now().Format("20060102-150405.000")
+		case "{test}":
+			if s.testName == "" {
+				return "sequence"
+			}
+			return fileSafe(s.testName)
+		case "{step}":
+			if s.step == "" {
+				return "step"
+			}
+			return fileSafe(s.step)
+		case "{seq}":
+			if !counted {
+				s.fileCount++
+				counted = true
+			}
+			return strconv.Itoa(s.fileCount)
+		}
+		if err == nil {
+			err = fmt.Errorf("Unknown token %s in the file name '%s'. The known tokens are {time}, {test}, "+
+				"{step} and {seq}", token, template)
+		}
+		return token
+	})
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tebeka/selenium"
+)
+
+// TimeMatch is for testing the date and time value of strings in elements
+type TimeMatch struct {
+	testName string
+	value    func(selenium.WebElement) (time.Time, error)
+	e        *Elements
+}
+
+// AsTime parses the string value as a time with the layout, as used by time.Parse, for time comparisons.  Values
+// without a time zone are parsed as UTC
+func (s *StringMatch) AsTime(layout string) *TimeMatch {
+	return s.AsTimeIn(layout, time.UTC)
+}
+
+// AsTimeIn parses the string value as a time with the layout in the location, as used by time.ParseInLocation,
+// for values shown in the user's time zone
+func (s *StringMatch) AsTimeIn(layout string, loc *time.Location) *TimeMatch {
+	return &TimeMatch{
+		testName: s.testName,
+		value: func(we selenium.WebElement) (time.Time, error) {
+			val, err := s.value(we)
+			if err != nil {
+				return time.Time{}, err
+			}
+			t, err := time.ParseInLocation(layout, strings.TrimSpace(val), loc)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("The element's %s '%s' is not a time in the layout '%s': %s",
+					s.testName, val, layout, err)
+			}
+			return t, nil
+		},
+		e: s.e,
+	}
+}
+
+// check parses the element's value before running the time comparison
+func (m *TimeMatch) check(fn func(val time.Time) error) func(selenium.WebElement) error {
+	return func(we selenium.WebElement) error {
+		val, err := m.value(we)
+		if err != nil {
+			return err
+		}
+		return fn(val)
+	}
+}
+
+// Before tests if the time value is before the passed in time
+func (m *TimeMatch) Before(t time.Time) *Elements {
+	return m.e.test(m.testName+" Before", m.check(func(val time.Time) error {
+		if !val.Before(t) {
+			return fmt.Errorf("The element's %s is not before %s. Got %s", m.testName, t, val)
+		}
+		return nil
+	}))
+}
+
+// After tests if the time value is after the passed in time
+func (m *TimeMatch) After(t time.Time) *Elements {
+	return m.e.test(m.testName+" After", m.check(func(val time.Time) error {
+		if !val.After(t) {
+			return fmt.Errorf("The element's %s is not after %s. Got %s", m.testName, t, val)
+		}
+		return nil
+	}))
+}
+
+// Within tests if the time value is within the duration either side of the passed in time, such as
+// Within(5*time.Minute, time.Now())
+func (m *TimeMatch) Within(d time.Duration, of time.Time) *Elements {
+	return m.e.test(m.testName+" Within", m.check(func(val time.Time) error {
+		diff := val.Sub(of)
+		if diff < -d || diff > d {
+			return fmt.Errorf("The element's %s is not within %s of %s. Got %s", m.testName, d, of, val)
+		}
+		return nil
+	}))
+}
+
+// Equals tests if the time value is the same instant as the passed in time, after both are truncated to the
+// precision, such as time.Minute for values which don't show seconds
+func (m *TimeMatch) Equals(t time.Time, precision time.Duration) *Elements {
+	return m.e.test(m.testName+" Equals", m.check(func(val time.Time) error {
+		if !val.Truncate(precision).Equal(t.Truncate(precision)) {
+			return fmt.Errorf("The element's %s does not equal %s to the %s. Got %s", m.testName, t, precision,
+				val)
+		}
+		return nil
+	}))
+}
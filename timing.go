@@ -0,0 +1,62 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import "time"
+
+// StepTiming is how long a step in the sequence took to run
+type StepTiming struct {
+	Group    string // the names of the groups the step is in, see Sequence.Group
+	Name     string // the step's stage
+	Step     string // the step's name, see Sequence.Step
+	Selector string
+	Caller   string
+	Start    time.Time
+	Duration time.Duration // total time taken, including any retries
+	Attempts int
+	// AttemptDurations is how long each attempt at the step took, when it was retried by Eventually
+	AttemptDurations []time.Duration
+	Passed           bool
+}
+
+// RecordTimings records how long each step in the sequence takes to run, for retrieving with Timings
+func (s *Sequence) RecordTimings() *Sequence {
+	s.recordTimings = true
+	return s
+}
+
+// Timings returns the timings of each step run in the sequence so far.  Timings are only recorded after
+// RecordTimings is called
+func (s *Sequence) Timings() []StepTiming {
+	s.logEnd()
+	return s.timings
+}
+
+// recordTiming records the timing of a finished step.  Retries of a step are added to the step's existing timing
+func (s *Sequence) recordTiming(record *stepRecord, duration time.Duration) {
+	if record.attempt > 0 {
+		for i := len(s.timings) - 1; i >= 0; i-- {
+			t := &s.timings[i]
+			if t.Group != record.group || t.Name != record.name || t.Selector != record.selector {
+				continue
+			}
+			t.Duration = time.Since(t.Start)
+			t.Attempts++
+			t.AttemptDurations = append(t.AttemptDurations, duration)
+			t.Passed = s.err == nil
+			return
+		}
+	}
+	s.timings = append(s.timings, StepTiming{
+		Group:            record.group,
+		Name:             record.name,
+		Step:             s.step,
+		Selector:         record.selector,
+		Caller:           record.caller,
+		Start:            record.start,
+		Duration:         duration,
+		Attempts:         1,
+		AttemptDurations: []time.Duration{duration},
+		Passed:           s.err == nil,
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+const parentScript = `
+var parents = [];
+for (var i = 0; i < arguments[0].length; i++) {
+	var p = arguments[0][i].parentElement;
+	if (p && parents.indexOf(p) === -1) {
+		parents.push(p);
+	}
+}
+return parents;`
+
+// scriptElements executes the passed in script and decodes the elements it returns.  The script must return
+// an array of elements
+func (s *Sequence) scriptElements(script string, args ...interface{}) ([]selenium.WebElement, error) {
+	raw, err := s.driver.ExecuteScriptRaw(script, args)
+	if err != nil {
+		return nil, err
+	}
+	return s.driver.DecodeElements(raw)
+}
+
+// traverse returns a new Elements built by mapping the current selection through fn.  If the current selection
+// can be re-selected, the new Elements will re-select and re-map it when retried with Eventually
+func (e *Elements) traverse(stage, selector string,
+	fn func(elems []selenium.WebElement) ([]selenium.WebElement, error)) *Elements {
+	newE := &Elements{
+		seq:      e.seq,
+		selector: selector,
+	}
+
+	if e.selectFunc != nil {
+		newE.selectFunc = func(string) ([]selenium.WebElement, error) {
+			elems, err := e.selectFunc(e.selector)
+			if err != nil {
+				return nil, err
+			}
+			return fn(elems)
+		}
+	}
+
+	if e.seq.failed() {
+		return newE
+	}
+
+	newE.last = func() *Elements {
+		if e.seq.stopped(stage, selector, 2) {
+			return newE
+		}
+		var err error
+		if newE.selectFunc != nil {
+			newE.elems, err = newE.selectFunc(newE.selector)
+		} else {
+			newE.elems, err = fn(e.elems)
+		}
+		if err != nil {
+			newE.seq.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: e.seq.caller(2),
+			}
+		}
+		return newE
+	}
+	return newE.last()
+}
+
+// Parent returns a new Elements containing the parent of each of the selected elements.  Elements which share
+// a parent will only have that parent included once
+func (e *Elements) Parent() *Elements {
+	return e.traverse("Parent", fmt.Sprintf("%s (parent)", e.selector),
+		func(elems []selenium.WebElement) ([]selenium.WebElement, error) {
+			for i := range elems {
+				tag, err := elems[i].TagName()
+				if err != nil {
+					return nil, err
+				}
+				if tag == "html" {
+					return nil, fmt.Errorf("Element %s is the document root and has no parent",
+						elementString(elems[i]))
+				}
+			}
+			return e.seq.scriptElements(parentScript, elems)
+		})
+}
+
+const siblingScript = `
+var sibling = arguments[0];
+do {
+	sibling = arguments[2] ? sibling.nextElementSibling : sibling.previousElementSibling;
+} while (sibling && arguments[1] && !sibling.matches(arguments[1]));
+return sibling ? [sibling] : [];`
+
+// Next returns a new Elements containing the next sibling of each of the selected elements.  If a selector is
+// passed in, the first following sibling matching that selector is used instead
+func (e *Elements) Next(selector ...string) *Elements {
+	filter := strings.Join(selector, ", ")
+	return e.traverse("Next", siblingSelector(e.selector, "next", filter), e.siblings("next", filter))
+}
+
+// Prev returns a new Elements containing the previous sibling of each of the selected elements.  If a selector is
+// passed in, the first preceding sibling matching that selector is used instead
+func (e *Elements) Prev(selector ...string) *Elements {
+	filter := strings.Join(selector, ", ")
+	return e.traverse("Prev", siblingSelector(e.selector, "previous", filter), e.siblings("previous", filter))
+}
+
+func siblingSelector(selector, direction, filter string) string {
+	if filter != "" {
+		return fmt.Sprintf("%s (%s sibling %s)", selector, direction, filter)
+	}
+	return fmt.Sprintf("%s (%s sibling)", selector, direction)
+}
+
+func (e *Elements) siblings(direction, filter string) func([]selenium.WebElement) ([]selenium.WebElement, error) {
+	return func(elems []selenium.WebElement) ([]selenium.WebElement, error) {
+		var found []selenium.WebElement
+		for i := range elems {
+			sibling, err := e.seq.scriptElements(siblingScript, elems[i], filter, direction == "next")
+			if err != nil {
+				return nil, err
+			}
+			if len(sibling) == 0 {
+				if filter != "" {
+					return nil, fmt.Errorf("Element %s has no %s sibling matching '%s'", elementString(elems[i]),
+						direction, filter)
+				}
+				return nil, fmt.Errorf("Element %s has no %s sibling", elementString(elems[i]), direction)
+			}
+			found = append(found, sibling...)
+		}
+		return found, nil
+	}
+}
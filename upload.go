@@ -0,0 +1,181 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// UploadFile sets the files at the passed in local paths on the selected file input elements.  Multiple files
+// can only be set on inputs with the multiple attribute.  If the sequence's RemoteURL is set, the files are first
+// pushed to the remote WebDriver server, so that the browser can find them, see Start
+func (e *Elements) UploadFile(localPaths ...string) *Elements {
+	return e.test("Upload File", func(we selenium.WebElement) error {
+		if len(localPaths) == 0 {
+			return fmt.Errorf("No files were specified for upload")
+		}
+		paths := make([]string, len(localPaths))
+		for i := range localPaths {
+			path, err := filepath.Abs(localPaths[i])
+			if err != nil {
+				return err
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("Cannot upload file: %s", err)
+			}
+			if info.IsDir() {
+				return fmt.Errorf("Cannot upload %s, it is a directory", path)
+			}
+			paths[i] = path
+		}
+
+		tag, err := we.TagName()
+		if err != nil {
+			return err
+		}
+		inputType, err := we.GetAttribute("type")
+		if err != nil {
+			return err
+		}
+		if tag != "input" || strings.ToLower(inputType) != "file" {
+			return fmt.Errorf("Element is not a file input")
+		}
+		if len(paths) > 1 {
+			multiple, err := we.GetAttribute("multiple")
+			if err != nil {
+				return err
+			}
+			if multiple == "" || multiple == "false" {
+				return fmt.Errorf("Cannot upload %d files to an input without the multiple attribute", len(paths))
+			}
+		}
+
+		if e.seq.RemoteURL != "" {
+			for i := range paths {
+				paths[i], err = e.seq.pushFile(paths[i])
+				if err != nil {
+					return fmt.Errorf("Pushing file %s to the remote WebDriver failed: %s", localPaths[i], err)
+				}
+			}
+		}
+
+		return we.SendKeys(strings.Join(paths, "\n"))
+	})
+}
+
+// pushFile zips the file and uploads it to the remote WebDriver server, returning the file's path on the remote
+// server
+func (s *Sequence) pushFile(path string) (string, error) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	w, err := zw.Create(filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(w, f)
+	if err != nil {
+		return "", err
+	}
+	err = zw.Close()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"file": base64.StdEncoding.EncodeToString(buf.Bytes()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	res, err := s.remoteRequest(http.MethodPost, "/file", body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		// newer selenium servers moved the upload endpoint
+		res.Body.Close()
+		res, err = s.remoteRequest(http.MethodPost, "/se/file", body)
+		if err != nil {
+			return "", err
+		}
+	}
+	defer res.Body.Close()
+
+	result := struct {
+		Value json.RawMessage `json:"value"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Remote WebDriver returned status %d: %s", res.StatusCode, result.Value)
+	}
+
+	remotePath := ""
+	err = json.Unmarshal(result.Value, &remotePath)
+	if err != nil {
+		return "", fmt.Errorf("Unexpected response from remote WebDriver: %s", result.Value)
+	}
+	return remotePath, nil
+}
+
+// remoteRequest sends a request for the path under the driver's session to the remote WebDriver server, with the
+// same http client the driver uses.  A non-nil body is sent as json.  The request is cancelled with the sequence's
+// context, and times out after EventualTimeout or at the sequence's deadline
+func (s *Sequence) remoteRequest(method, path string, body []byte) (*http.Response, error) {
+	uri := strings.TrimSuffix(s.RemoteURL, "/") + "/session/" + s.driver.SessionID() + path
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	ctx, cancel := context.WithTimeout(s.Context(), s.clampTimeout(s.EventualTimeout))
+	req, err := http.NewRequestWithContext(ctx, method, uri, reader)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := selenium.HTTPClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnClose cancels the request's context once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
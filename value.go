@@ -0,0 +1,145 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValueMatch is for testing string values which aren't part of an element, such as the text of an alert
+type ValueMatch struct {
+	testName string
+	value    func() (string, error)
+	val      string
+	s        *Sequence
+}
+
+func (v *ValueMatch) test(testName string, fn func() error) *Sequence {
+	stage := v.testName + " " + testName
+	v.s.last = func() *Sequence {
+		if v.s.failed() {
+			return v.s
+		}
+		if v.s.stopped(stage, "", 2) {
+			return v.s
+		}
+		val, err := v.value()
+		if sErr, ok := err.(*Error); ok {
+			sErr.Caller = v.s.caller(2)
+			v.s.err = sErr
+			return v.s
+		}
+		if err != nil {
+			v.s.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: v.s.caller(2),
+			}
+			return v.s
+		}
+		v.val = val
+		err = fn()
+		if err != nil {
+			v.s.err = &Error{
+				Stage:  stage,
+				Err:    err,
+				Caller: v.s.caller(2),
+			}
+		}
+		return v.s
+	}
+	return v.s.last()
+}
+
+// Equals tests if the value matches the passed in value exactly
+func (v *ValueMatch) Equals(match string) *Sequence {
+	return v.test("Equals", func() error {
+		if v.val != match {
+			return fmt.Errorf("The %s does not equal '%s'. Got '%s'", v.testName, match, v.val)
+		}
+		return nil
+	})
+}
+
+// NotEquals tests if the value does not match the passed in value exactly
+func (v *ValueMatch) NotEquals(match string) *Sequence {
+	return v.test("Not Equals", func() error {
+		if v.val == match {
+			return fmt.Errorf("The %s equals '%s'", v.testName, match)
+		}
+		return nil
+	})
+}
+
+// Contains tests if the value contains the passed in value
+func (v *ValueMatch) Contains(match string) *Sequence {
+	return v.test("Contains", func() error {
+		if !strings.Contains(v.val, match) {
+			return fmt.Errorf("The %s does not contain '%s'. Got '%s'", v.testName, match, v.val)
+		}
+		return nil
+	})
+}
+
+// NotContains tests if the value does not contain the passed in value
+func (v *ValueMatch) NotContains(match string) *Sequence {
+	return v.test("Not Contains", func() error {
+		if strings.Contains(v.val, match) {
+			return fmt.Errorf("The %s contains '%s'. Got '%s'", v.testName, match, v.val)
+		}
+		return nil
+	})
+}
+
+// StartsWith tests if the value starts with the passed in value
+func (v *ValueMatch) StartsWith(match string) *Sequence {
+	return v.test("Starts With", func() error {
+		if !strings.HasPrefix(v.val, match) {
+			return fmt.Errorf("The %s does not start with '%s'. Got '%s'", v.testName, match, v.val)
+		}
+		return nil
+	})
+}
+
+// EndsWith tests if the value ends with the passed in value
+func (v *ValueMatch) EndsWith(match string) *Sequence {
+	return v.test("Ends With", func() error {
+		if !strings.HasSuffix(v.val, match) {
+			return fmt.Errorf("The %s does not end with '%s'. Got '%s'", v.testName, match, v.val)
+		}
+		return nil
+	})
+}
+
+// Regexp tests if the value matches the regular expression
+func (v *ValueMatch) Regexp(exp *regexp.Regexp) *Sequence {
+	return v.test("Matches RegExp", func() error {
+		if !exp.MatchString(v.val) {
+			return fmt.Errorf("The %s does not match the regular expression '%s'. Got '%s'", v.testName, exp,
+				v.val)
+		}
+		return nil
+	})
+}
+
+// Empty tests if the value is empty
+func (v *ValueMatch) Empty() *Sequence {
+	return v.test("Empty", func() error {
+		if v.val != "" {
+			return fmt.Errorf("The %s is not empty. Got '%s'", v.testName, v.val)
+		}
+		return nil
+	})
+}
+
+// NotEmpty tests if the value is not empty
+func (v *ValueMatch) NotEmpty() *Sequence {
+	return v.test("Not Empty", func() error {
+		if v.val == "" {
+			return fmt.Errorf("The %s is empty", v.testName)
+		}
+		return nil
+	})
+}
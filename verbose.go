@@ -0,0 +1,97 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// verboseLock serializes writes to verbose writers, which may be shared by sequences in parallel tests
+var verboseLock sync.Mutex
+
+// stepRecord is a step which is being logged by a verbose sequence
+type stepRecord struct {
+	group    string
+	name     string
+	selector string
+	caller   string
+	start    time.Time
+	attempt  int
+}
+
+// Verbose writes a line to w for every step in the sequence as it runs, with whether it passed, how long it took
+// and where it was called from.  Steps retried by Eventually are logged for each attempt.  If w is nil, lines are
+// written to the sequence's output, see SetOutput
+func (s *Sequence) Verbose(w io.Writer) *Sequence {
+	if w == nil {
+		w = sequenceOutput{s}
+	}
+	s.verbose = w
+	return s
+}
+
+// tracking returns whether the sequence's steps are being logged or timed
+func (s *Sequence) tracking() bool {
+	return s.verbose != nil || s.recordTimings || len(s.reporters) > 0
+}
+
+// logStart starts tracking the passed in step, ending the previous step if it's still running
+func (s *Sequence) logStart(step, selector, at string) {
+	if !s.tracking() {
+		return
+	}
+	s.logEnd()
+	s.current = &stepRecord{
+		group:    s.groupPath(),
+		name:     step,
+		selector: selector,
+		caller:   at,
+		start:    time.Now(),
+		attempt:  s.attempt,
+	}
+	s.reportStarted(s.current)
+}
+
+// logEnd ends tracking the currently running step, writing its log line and recording its timing
+func (s *Sequence) logEnd() {
+	if s.current == nil {
+		return
+	}
+	record := s.current
+	s.current = nil
+	duration := time.Since(record.start)
+
+	if s.recordTimings {
+		s.recordTiming(record, duration)
+	}
+	s.reportStepFinished(record, duration)
+	if s.verbose == nil {
+		return
+	}
+
+	result := "PASS"
+	if s.err != nil {
+		result = "FAIL"
+	}
+	name := record.name
+	if record.group != "" {
+		name = record.group + groupSeparator + name
+	}
+	if record.selector != "" {
+		name += " '" + record.selector + "'"
+	}
+	if s.step != "" {
+		name += " [" + s.step + "]"
+	}
+	if record.attempt > 0 {
+		name += fmt.Sprintf(" (retry %d)", record.attempt)
+	}
+
+	verboseLock.Lock()
+	defer verboseLock.Unlock()
+	fmt.Fprintf(s.verbose, "%s  %s  %s  %s\n", result, redact(name, s.redacted), duration.Round(time.Millisecond),
+		record.caller)
+}
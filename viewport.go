@@ -0,0 +1,87 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/tebeka/selenium"
+)
+
+const viewportScript = `
+var rect = arguments[0].getBoundingClientRect();
+return [rect.left, rect.top, rect.width, rect.height, window.innerWidth, window.innerHeight];`
+
+// viewportRect is an element's bounding rect, and the size of the viewport it's in
+type viewportRect struct {
+	left, top, width, height float64
+	viewWidth, viewHeight    float64
+}
+
+func (r viewportRect) String() string {
+	return fmt.Sprintf("rect (left %v, top %v, %vx%v) and the viewport is %vx%v", r.left, r.top, r.width,
+		r.height, r.viewWidth, r.viewHeight)
+}
+
+// partial returns whether any of the rect is in the viewport
+func (r viewportRect) partial() bool {
+	return r.left+r.width > 0 && r.top+r.height > 0 && r.left < r.viewWidth && r.top < r.viewHeight
+}
+
+// full returns whether all of the rect is in the viewport
+func (r viewportRect) full() bool {
+	return r.left >= 0 && r.top >= 0 && r.left+r.width <= r.viewWidth && r.top+r.height <= r.viewHeight
+}
+
+// InViewport tests if any part of the elements is scrolled into the browser's viewport.  WebDriver considers
+// elements scrolled out of view to be visible, so this is for testing things like lazy loading and sticky headers
+func (e *Elements) InViewport() *Elements {
+	return e.viewport("In Viewport", func(r viewportRect) error {
+		if !r.partial() {
+			return fmt.Errorf("Element is not in the viewport, its %s", r)
+		}
+		return nil
+	})
+}
+
+// FullyInViewport tests if all of the elements are inside the browser's viewport
+func (e *Elements) FullyInViewport() *Elements {
+	return e.viewport("Fully In Viewport", func(r viewportRect) error {
+		if !r.full() {
+			return fmt.Errorf("Element is not fully in the viewport, its %s", r)
+		}
+		return nil
+	})
+}
+
+// NotInViewport tests if the elements are entirely outside of the browser's viewport
+func (e *Elements) NotInViewport() *Elements {
+	return e.viewport("Not In Viewport", func(r viewportRect) error {
+		if r.partial() {
+			return fmt.Errorf("Element is in the viewport, its %s", r)
+		}
+		return nil
+	})
+}
+
+func (e *Elements) viewport(testName string, fn func(r viewportRect) error) *Elements {
+	defer e.AddCallerSkip(1).AddCallerSkip(-1)
+	return e.test(testName, func(we selenium.WebElement) error {
+		result, err := e.seq.driver.ExecuteScript(viewportScript, []interface{}{we})
+		if err != nil {
+			return err
+		}
+		nums, err := floats(result, 6)
+		if err != nil {
+			return fmt.Errorf("Unexpected bounding rect result: %s", err)
+		}
+		return fn(viewportRect{
+			left:       nums[0],
+			top:        nums[1],
+			width:      nums[2],
+			height:     nums[3],
+			viewWidth:  nums[4],
+			viewHeight: nums[5],
+		})
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tebeka/selenium"
+)
+
+// WindowMatcher determines which window to switch to in SwitchToWindow
+type WindowMatcher func(index int, title, url string) bool
+
+// WindowTitle matches the window whose title equals the passed in title
+func WindowTitle(title string) WindowMatcher {
+	return func(index int, t, url string) bool {
+		return t == title
+	}
+}
+
+// WindowURL matches the window whose url contains the passed in value
+func WindowURL(contains string) WindowMatcher {
+	return func(index int, title, url string) bool {
+		return strings.Contains(url, contains)
+	}
+}
+
+// WindowIndex matches the window at the passed in index, in the order the driver returns the window handles
+func WindowIndex(i int) WindowMatcher {
+	return func(index int, title, url string) bool {
+		return index == i
+	}
+}
+
+// SwitchToWindow switches the driver to the first window or tab matched by the matcher.  If no window matches, or
+// checking a window fails, the driver is switched back to the window it was in
+func (s *Sequence) SwitchToWindow(matcher WindowMatcher) *Sequence {
+	return s.test("Switch To Window", func(d selenium.WebDriver) error {
+		current, err := d.CurrentWindowHandle()
+		if err != nil {
+			return err
+		}
+		handles, err := d.WindowHandles()
+		if err != nil {
+			return err
+		}
+
+		// switching windows leaves any frames, so the frames are cleared even when switching back to current
+		s.frames = nil
+		restore := func(err error) error {
+			rErr := d.SwitchWindow(current)
+			if rErr != nil {
+				return fmt.Errorf("%s. Switching back to the original window failed: %s", err, rErr)
+			}
+			return err
+		}
+
+		windows := ""
+		for i := range handles {
+			err = d.SwitchWindow(handles[i])
+			if err != nil {
+				return restore(err)
+			}
+			title, err := d.Title()
+			if err != nil {
+				return restore(err)
+			}
+			uri, err := d.CurrentURL()
+			if err != nil {
+				return restore(err)
+			}
+			if matcher(i, title, uri) {
+				if handles[i] != current {
+					s.windows = append(s.windows, current)
+				}
+				return nil
+			}
+			windows += fmt.Sprintf("\n\t%d: '%s' (%s)", i, title, uri)
+		}
+
+		return restore(fmt.Errorf("No window matched. Open windows: %s", windows))
+	})
+}
+
+// CloseWindow closes the current window or tab and switches back to the window that was active before it
+func (s *Sequence) CloseWindow() *Sequence {
+	return s.test("Close Window", func(d selenium.WebDriver) error {
+		current, err := d.CurrentWindowHandle()
+		if err != nil {
+			return err
+		}
+		err = d.CloseWindow(current)
+		if err != nil {
+			return err
+		}
+		s.frames = nil
+
+		handles, err := d.WindowHandles()
+		if err != nil {
+			return err
+		}
+		if len(handles) == 0 {
+			return nil
+		}
+
+		for len(s.windows) > 0 {
+			previous := s.windows[len(s.windows)-1]
+			s.windows = s.windows[:len(s.windows)-1]
+			for i := range handles {
+				if handles[i] == previous {
+					return d.SwitchWindow(previous)
+				}
+			}
+		}
+		return d.SwitchWindow(handles[0])
+	})
+}
+
+// WindowCount tests that the number of open windows and tabs matches the argument
+func (s *Sequence) WindowCount(count int) *Sequence {
+	return s.test("Window Count", func(d selenium.WebDriver) error {
+		handles, err := d.WindowHandles()
+		if err != nil {
+			return err
+		}
+		if len(handles) != count {
+			return fmt.Errorf("Invalid window count wanted %d got %d", count, len(handles))
+		}
+		return nil
+	})
+}
+
+// ResizeWindow resizes the current window to the passed in width and height in pixels
+func (s *Sequence) ResizeWindow(width, height int) *Sequence {
+	return s.test("Resize Window", func(d selenium.WebDriver) error {
+		return s.resizeWindow(width, height)
+	})
+}
+
+// MaximizeWindow maximizes the current window
+func (s *Sequence) MaximizeWindow() *Sequence {
+	return s.test("Maximize Window", func(d selenium.WebDriver) error {
+		handle, err := d.CurrentWindowHandle()
+		if err != nil {
+			return err
+		}
+		return d.MaximizeWindow(handle)
+	})
+}
+
+// WithViewport resizes the current window so that its viewport is the passed in width and height, runs the
+// steps in fn, and then restores the window's previous size.  The previous size is restored even if the steps
+// in fn fail
+func (s *Sequence) WithViewport(width, height int, fn func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+
+	size, err := s.windowSize()
+	if err != nil {
+		s.err = &Error{
+			Stage:  "With Viewport",
+			Err:    err,
+			Caller: s.caller(0),
+		}
+		return s
+	}
+
+	err = s.resizeWindow(width+size[0]-size[2], height+size[1]-size[3])
+	if err != nil {
+		s.err = &Error{
+			Stage:  "With Viewport",
+			Err:    err,
+			Caller: s.caller(0),
+		}
+		return s
+	}
+
+	s = fn(s)
+	// screenshot any failure in fn at the viewport it failed at
+	s.endStep()
+
+	err = s.resizeWindow(size[0], size[1])
+	if err != nil && s.err == nil {
+		s.err = &Error{
+			Stage:  "With Viewport Restore",
+			Err:    err,
+			Caller: s.caller(0),
+		}
+	}
+	return s
+}
+
+func (s *Sequence) resizeWindow(width, height int) error {
+	handle, err := s.driver.CurrentWindowHandle()
+	if err != nil {
+		return err
+	}
+	return s.driver.ResizeWindow(handle, width, height)
+}
+
+// windowSize returns the outer width and height, and the inner (viewport) width and height of the current window
+func (s *Sequence) windowSize() ([4]int, error) {
+	var size [4]int
+	result, err := s.driver.ExecuteScript(
+		"return [window.outerWidth, window.outerHeight, window.innerWidth, window.innerHeight];", nil)
+	if err != nil {
+		return size, err
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != len(size) {
+		return size, fmt.Errorf("Unexpected window size result: %v", result)
+	}
+	for i := range values {
+		f, ok := values[i].(float64)
+		if !ok {
+			return size, fmt.Errorf("Unexpected window size result: %v", result)
+		}
+		size[i] = int(f)
+	}
+	return size, nil
+}
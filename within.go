@@ -0,0 +1,58 @@
+// Copyright (c) 2017-2018 Townsourced Inc.
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scope is a container element which Find selects within, see Sequence.Within
+type scope struct {
+	selector string
+	elems    *Elements
+}
+
+// Within runs fn with Find selecting only elements inside the elements matching the container selector, such as
+// everything in a checkout panel of a page object.  Within calls can be nested, with each container selected within
+// the one outside it.  The container is re-selected along with the elements found in it, so Eventually inside fn
+// can wait for the container to be rendered.  Errors from inside fn mention the container they were scoped to
+func (s *Sequence) Within(selector string, fn func(s *Sequence) *Sequence) *Sequence {
+	if s.failed() {
+		return s
+	}
+	s.AddCallerSkip(1)
+	container := s.Find(selector)
+	s.AddCallerSkip(-1)
+	if s.err != nil {
+		return s
+	}
+
+	s.scopes = append(s.scopes, scope{selector: selector, elems: container})
+	path := s.scopePath()
+	skip := s.callerSkip
+	fn(s)
+	s.callerSkip = skip
+	s.scopes = s.scopes[:len(s.scopes)-1]
+
+	if s.err != nil && !s.err.scoped {
+		s.err.scoped = true
+		s.err.Err = fmt.Errorf("%w (within '%s')", s.err.Err, path)
+	}
+	return s
+}
+
+// scopePath returns the selectors of the containers Find is currently scoped to, from the outermost in
+func (s *Sequence) scopePath() string {
+	selectors := make([]string, len(s.scopes))
+	for i := range s.scopes {
+		selectors[i] = s.scopes[i].selector
+	}
+	return strings.Join(selectors, " ")
+}
+
+// findWithin finds the elements matching the selector inside the innermost container from Within
+func (s *Sequence) findWithin(selector string) *Elements {
+	defer s.AddCallerSkip(2).AddCallerSkip(-2)
+	return s.scopes[len(s.scopes)-1].elems.FindChildren(selector)
+}